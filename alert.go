@@ -0,0 +1,59 @@
+package daikin
+
+// Alert threshold violations, passed to SetAlertThresholds callbacks.
+const (
+	ViolationAboveMax = "above_max"
+	ViolationBelowMin = "below_min"
+)
+
+// alertThreshold is a threshold registered with SetAlertThresholds.
+type alertThreshold struct {
+	min, max Temperature
+	callback func(d *Daikin, violation string)
+	// violation is the current violation, or empty if within range.
+	violation string
+}
+
+// SetAlertThresholds registers a callback, called when GetSensorInfo finds
+// the indoor temperature has gone above max or below min, with violation
+// ViolationAboveMax or ViolationBelowMin. It is called once when the
+// temperature leaves the range, and again only after it has returned.
+// Multiple thresholds may be registered, each with its own callback.
+func SetAlertThresholds(d *Daikin, min, max Temperature, callback func(d *Daikin, violation string)) {
+	d.hooks.mu.Lock()
+	defer d.hooks.mu.Unlock()
+	d.hooks.alerts = append(d.hooks.alerts, &alertThreshold{min: min, max: max, callback: callback})
+}
+
+// ClearAlertThresholds removes all thresholds registered on the unit with
+// SetAlertThresholds.
+func ClearAlertThresholds(d *Daikin) {
+	d.hooks.mu.Lock()
+	defer d.hooks.mu.Unlock()
+	d.hooks.alerts = nil
+}
+
+// checkAlerts calls the callbacks of thresholds newly violated by s.
+func (d *Daikin) checkAlerts(s *SensorInfo) {
+	var fire []func()
+	d.hooks.mu.Lock()
+	for _, a := range d.hooks.alerts {
+		violation := ""
+		switch {
+		case s.HomeTemperature > a.max:
+			violation = ViolationAboveMax
+		case s.HomeTemperature < a.min:
+			violation = ViolationBelowMin
+		}
+		if violation != "" && violation != a.violation {
+			cb := a.callback
+			fire = append(fire, func() { cb(d, violation) })
+		}
+		a.violation = violation
+	}
+	d.hooks.mu.Unlock()
+	// Callbacks are called unlocked, so may register or clear thresholds.
+	for _, f := range fire {
+		f()
+	}
+}
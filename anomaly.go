@@ -0,0 +1,47 @@
+package daikin
+
+import "math"
+
+// AnomalyDetector finds indoor temperature readings which deviate from the
+// recent average, eg due to a sensor fault or a sudden change in weather.
+type AnomalyDetector struct {
+	// Threshold is the deviation from the average, in degrees, above which
+	// a reading is anomalous.
+	Threshold Temperature
+	// Window is the number of previous readings averaged.
+	Window int
+}
+
+// anomalyHook is a detector registered with OnAnomaly.
+type anomalyHook struct {
+	det AnomalyDetector
+	buf *RingBuffer
+	fn  func(d *Daikin, reading Temperature)
+}
+
+// OnAnomaly registers a callback, called when GetSensorInfo fetches an
+// indoor temperature which deviates from the average of the previous
+// det.Window readings by more than det.Threshold. It is not called until
+// Window readings have been fetched. Multiple callbacks may be registered.
+func (d *Daikin) OnAnomaly(det AnomalyDetector, fn func(d *Daikin, reading Temperature)) {
+	d.hooks.mu.Lock()
+	defer d.hooks.mu.Unlock()
+	d.hooks.anomaly = append(d.hooks.anomaly, &anomalyHook{
+		det: det,
+		buf: &RingBuffer{Size: det.Window},
+		fn:  fn,
+	})
+}
+
+// check calls the callback if the reading is anomalous, and then adds it
+// to the window.
+func (h *anomalyHook) check(d *Daikin, s *SensorInfo) {
+	reading := s.HomeTemperature
+	if n := len(h.buf.Slice()); n > 0 && n >= h.det.Window {
+		avg := h.buf.Avg().HomeTemperature
+		if math.Abs(float64(reading-avg)) > float64(h.det.Threshold) {
+			h.fn(d, reading)
+		}
+	}
+	h.buf.Add(SensorSnapshot{SensorInfo: *s, Address: d.Address})
+}
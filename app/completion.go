@@ -0,0 +1,150 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+// modeNames are the completions for --mode.
+var modeNames = []string{"heat", "cool", "fan", "dehumidify", "auto"}
+
+// completion handles the completion subcommand, printing the completion
+// script for the given shell. The "addresses" shell is used by the scripts
+// to complete --address with the devices found by a quick discovery.
+func completion(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s completion bash|zsh|fish", progName())
+	}
+	switch args[0] {
+	case "bash":
+		bashCompletion(os.Stdout)
+	case "zsh":
+		zshCompletion(os.Stdout)
+	case "fish":
+		fishCompletion(os.Stdout)
+	case "addresses":
+		return completeAddresses(os.Stdout)
+	default:
+		return fmt.Errorf("unsupported shell %q: want bash, zsh or fish", args[0])
+	}
+	return nil
+}
+
+func progName() string {
+	return filepath.Base(os.Args[0])
+}
+
+// completeAddresses prints the addresses of devices found by a single
+// short discovery poll.
+func completeAddresses(w io.Writer) error {
+	n, err := daikin.NewNetwork(daikin.InterfaceOption(strings.Split(*ifName, ",")...))
+	if err != nil {
+		return err
+	}
+	n.PollInterval = 500 * time.Millisecond
+	if err := n.Discover(); err != nil {
+		return err
+	}
+	addrs := []string{}
+	for _, d := range n.DeviceList() {
+		addrs = append(addrs, d.Address)
+	}
+	sort.Strings(addrs)
+	for _, a := range addrs {
+		fmt.Fprintln(w, a)
+	}
+	return nil
+}
+
+// isBoolFlag returns whether the flag takes no value.
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// flags returns the command line flags, sorted by name.
+func flags() []*flag.Flag {
+	var fs []*flag.Flag
+	flag.VisitAll(func(f *flag.Flag) {
+		fs = append(fs, f)
+	})
+	return fs
+}
+
+func bashCompletion(w io.Writer) {
+	prog := progName()
+	var names []string
+	for _, f := range flags() {
+		names = append(names, "--"+f.Name)
+	}
+	fn := "_" + strings.ReplaceAll(prog, "-", "_")
+	fmt.Fprintf(w, `%s() {
+	local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"
+	case "$prev" in
+	-address|--address)
+		COMPREPLY=($(compgen -W "$(%s completion addresses 2>/dev/null)" -- "$cur"))
+		return ;;
+	-mode|--mode)
+		COMPREPLY=($(compgen -W "%s" -- "$cur"))
+		return ;;
+	-config|--config)
+		COMPREPLY=($(compgen -f -- "$cur"))
+		return ;;
+	esac
+	COMPREPLY=($(compgen -W "%s" -- "$cur"))
+}
+complete -F %s %s
+`, fn, prog, strings.Join(modeNames, " "), strings.Join(names, " "), fn, prog)
+}
+
+func zshCompletion(w io.Writer) {
+	prog := progName()
+	esc := strings.NewReplacer("'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`)
+	fmt.Fprintf(w, "#compdef %s\n\n", prog)
+	fmt.Fprintf(w, "_%s_addresses() {\n\tlocal -a addrs\n\taddrs=(${(f)\"$(%s completion addresses 2>/dev/null)\"})\n\t_describe 'address' addrs\n}\n\n", prog, prog)
+	fmt.Fprint(w, "_arguments")
+	for _, f := range flags() {
+		spec := fmt.Sprintf("--%s[%s]", f.Name, esc.Replace(f.Usage))
+		switch {
+		case isBoolFlag(f):
+		case f.Name == "address":
+			spec += ":address:_" + prog + "_addresses"
+		case f.Name == "mode":
+			spec += ":mode:(" + strings.Join(modeNames, " ") + ")"
+		case f.Name == "config":
+			spec += ":file:_files"
+		default:
+			spec += ":value: "
+		}
+		fmt.Fprintf(w, " \\\n\t'%s'", spec)
+	}
+	fmt.Fprintln(w)
+}
+
+func fishCompletion(w io.Writer) {
+	prog := progName()
+	esc := strings.NewReplacer(`\`, `\\`, "'", `\'`)
+	for _, f := range flags() {
+		fmt.Fprintf(w, "complete -c %s -l %s -d '%s'", prog, f.Name, esc.Replace(f.Usage))
+		switch {
+		case isBoolFlag(f):
+		case f.Name == "address":
+			fmt.Fprintf(w, " -x -a '(%s completion addresses 2>/dev/null)'", prog)
+		case f.Name == "mode":
+			fmt.Fprintf(w, " -x -a '%s'", strings.Join(modeNames, " "))
+		case f.Name == "config":
+			fmt.Fprint(w, " -r -F")
+		default:
+			fmt.Fprint(w, " -x")
+		}
+		fmt.Fprintln(w)
+	}
+}
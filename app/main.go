@@ -3,36 +3,194 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"github.com/buxtronix/go-daikin"
+	"github.com/buxtronix/go-daikin/config"
 	"github.com/golang/glog"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 var (
-	ifName  = flag.String("interface", "", "Interface to scan on")
-	address = flag.String("address", "", "Use device at specific address")
+	ifName    = flag.String("interface", "", "Interface to scan on, or a comma separated list")
+	addresses addressList
+	cfgFile   = flag.String("config", "", "YAML or TOML file listing devices to use")
 
 	powerOn  = flag.Bool("on", false, "Turn unit on")
 	powerOff = flag.Bool("off", false, "Turn unit off")
 
-	modeHeat = flag.Bool("heat", false, "Set to heating mode")
-	modeCool = flag.Bool("cool", false, "Set to cooling mode")
-	modeFan  = flag.Bool("fan", false, "Set to fan mode")
+	modeName       = flag.String("mode", "", "Set mode (heat, cool, fan, dehumidify, auto)")
+	modeHeat       = flag.Bool("heat", false, "Set to heating mode (deprecated, use --mode)")
+	modeCool       = flag.Bool("cool", false, "Set to cooling mode (deprecated, use --mode)")
+	modeFan        = flag.Bool("fan", false, "Set to fan mode (deprecated, use --mode)")
+	modeDehumidify = flag.Bool("dehumidify", false, "Set to dehumidify mode, same as --mode dehumidify")
+	modeAuto       = flag.Bool("auto", false, "Set to auto mode, same as --mode auto")
 
 	fanRate = flag.String("speed", "", "Fan speed (A, B, 1, 2, 3, 4, 5)")
 
+	powerful       = flag.Bool("powerful", false, "Enable powerful mode, if supported")
+	eco            = flag.Bool("eco", false, "Enable economy mode, if supported")
+	streamer       = flag.Bool("streamer", false, "Enable Streamer air purification, if supported")
+	comfortAirflow = flag.Bool("comfort-airflow", false, "Enable comfort airflow, if supported")
+	intelligentEye = flag.Bool("intelligent-eye", false, "Enable the Intelligent Eye sensor, if supported")
+
 	fanVertical   = flag.Bool("vertical", false, "Sweep louvres vertically")
 	fanHorizontal = flag.Bool("horizontal", false, "Sweep louvres horizontally")
 
-	setTemp = flag.Float64("temp", 22.0, "Temperature to set to")
+	setTemp    = flag.Float64("temp", 22.0, "Temperature to set to")
+	fahrenheit = flag.Bool("fahrenheit", false, "Display temperatures, and interpret --temp, in Fahrenheit")
+
+	jsonOut = flag.Bool("json", false, "Print devices as a JSON array instead of text")
+
+	sleep sleepFlag
 )
 
+// addressList is a flag which may be given multiple times.
+type addressList []string
+
+func (a *addressList) String() string {
+	return strings.Join(*a, ",")
+}
+
+func (a *addressList) Set(v string) error {
+	*a = append(*a, v)
+	return nil
+}
+
+// sleepFlag is a sleep timer duration and temperature change, eg "2h +2deg".
+type sleepFlag struct {
+	set      bool
+	duration time.Duration
+	delta    string
+}
+
+func (s *sleepFlag) String() string {
+	if !s.set {
+		return ""
+	}
+	return strings.TrimSpace(s.duration.String() + " " + s.delta)
+}
+
+func (s *sleepFlag) Set(v string) error {
+	fields := strings.Fields(v)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("want duration and temperature change, eg \"2h +2deg\"")
+	}
+	d, err := time.ParseDuration(fields[0])
+	if err != nil {
+		return err
+	}
+	s.set, s.duration = true, d
+	if len(fields) == 2 {
+		s.delta = fields[1]
+	}
+	return nil
+}
+
+// parseTempDelta parses a temperature change, eg "+2deg" or "-1.5".
+func parseTempDelta(s string) (daikin.Temperature, error) {
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "deg"), "°")
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid temperature change %q", s)
+	}
+	if *fahrenheit {
+		f = f * 5 / 9
+	}
+	return daikin.Temperature(f).Round(), nil
+}
+
+func init() {
+	flag.Var(&addresses, "address", "Use device at specific address or hostname, may be repeated")
+	flag.Var(&sleep, "sleep", "Run a sleep timer, gradually changing the set temperature, eg --sleep 2h +2deg")
+}
+
+// printf prints to stdout, unless JSON output is enabled.
+func printf(format string, a ...interface{}) {
+	if !*jsonOut {
+		fmt.Printf(format, a...)
+	}
+}
+
+// isFlagSet returns whether the named flag was given on the command line.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "completion" {
+		if err := completion(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "setback" {
+		if err := setback(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 	flag.Parse()
-	d, err := daikin.NewNetwork(
-		daikin.InterfaceOption(*ifName),
-		daikin.AddressOption(*address))
+	if *fahrenheit {
+		daikin.DefaultUnit = daikin.Fahrenheit
+	}
+	var sleepTimer daikin.SleepTimer
+	if sleep.set {
+		// The temperature change may be given as a separate argument.
+		if sleep.delta == "" && flag.NArg() == 1 {
+			sleep.delta = flag.Arg(0)
+		}
+		delta, err := parseTempDelta(sleep.delta)
+		if err != nil {
+			glog.Exitf("Invalid --sleep: %v", err)
+		}
+		sleepTimer = daikin.SleepTimer{Duration: sleep.duration, TempDelta: delta}
+	}
+	var mode daikin.Mode
+	if *modeName != "" {
+		if *modeHeat || *modeCool || *modeFan || *modeDehumidify || *modeAuto {
+			glog.Exit("--mode cannot be combined with --heat, --cool, --fan, --dehumidify or --auto")
+		}
+		var err error
+		if mode, err = daikin.ParseMode(*modeName); err != nil {
+			glog.Exitf("Unsupported mode %q: want heat, cool, fan, dehumidify or auto", *modeName)
+		}
+	}
+	opts := []daikin.Option{
+		daikin.InterfaceOption(strings.Split(*ifName, ",")...),
+	}
+	for _, a := range addresses {
+		if net.ParseIP(a) == nil && !strings.Contains(a, ":") {
+			opts = append(opts, daikin.AddressNameOption(a))
+			continue
+		}
+		opts = append(opts, daikin.AddressTokenOption(a, ""))
+	}
+	// Config devices are added after, so their tokens take precedence.
+	if *cfgFile != "" {
+		cfg, err := config.LoadFile(*cfgFile)
+		if err != nil {
+			glog.Exit(err)
+		}
+		opts = append(opts, cfg.Options()...)
+	}
+	d, err := daikin.NewNetwork(opts...)
 	if err != nil {
 		glog.Exit(err)
 	}
@@ -40,17 +198,19 @@ func main() {
 		glog.Exit(err)
 	}
 
-	fmt.Printf("Devices:\n")
-	for a, d := range d.Devices {
+	printf("Devices:\n")
+	devices := []*daikin.Daikin{}
+	for _, d := range d.DeviceList() {
+		a := d.Address
 		if err := d.GetControlInfo(); err != nil {
-			glog.Error(err)
+			fmt.Fprintf(os.Stderr, "Warning: %s unreachable, skipping: %v\n", a, err)
 			continue
 		}
 		if err := d.GetSensorInfo(); err != nil {
-			glog.Error(err)
+			fmt.Fprintf(os.Stderr, "Warning: %s unreachable, skipping: %v\n", a, err)
 			continue
 		}
-		fmt.Printf("Current %s:\n%s\n\n", a, d)
+		printf("Current %s:\n%s\n\n", a, d)
 		if *powerOn || *powerOff {
 			if *powerOn {
 				d.ControlInfo.Power = daikin.PowerOn
@@ -67,6 +227,15 @@ func main() {
 			if *modeFan {
 				d.ControlInfo.Mode = daikin.ModeFan
 			}
+			if *modeDehumidify {
+				d.ControlInfo.Mode = daikin.ModeDehumidify
+			}
+			if *modeAuto {
+				d.ControlInfo.Mode = daikin.ModeAuto
+			}
+			if *modeName != "" {
+				d.ControlInfo.Mode = mode
+			}
 
 			switch *fanRate {
 			case "A":
@@ -100,10 +269,31 @@ func main() {
 				d.ControlInfo.FanDir = daikin.FanDirStopped
 			}
 
-			if *setTemp > 0 {
+			if *fahrenheit && isFlagSet("temp") {
+				d.ControlInfo.Temperature = daikin.TemperatureFromFahrenheit(*setTemp)
+			} else if *setTemp > 0 {
 				d.ControlInfo.Temperature = daikin.Temperature(*setTemp)
 			}
-			fmt.Printf("Setting to new values:\n%s\n\n", d)
+			if isFlagSet("powerful") {
+				d.ControlInfo.Powerful = *powerful
+			}
+			if isFlagSet("eco") {
+				d.ControlInfo.Eco = *eco
+			}
+			if isFlagSet("streamer") {
+				d.ControlInfo.Streamer = *streamer
+			}
+			if isFlagSet("comfort-airflow") {
+				d.ControlInfo.ComfortAirflow = *comfortAirflow
+			}
+			if isFlagSet("intelligent-eye") {
+				d.ControlInfo.IntelligentEye = *intelligentEye
+			}
+			// Optional features are only sent if the model supports them.
+			if err := d.GetModelInfo(); err != nil {
+				glog.Warningf("Error getting model info: %v", err)
+			}
+			printf("Setting to new values:\n%s\n\n", d)
 
 			if err := d.SetControlInfo(); err != nil {
 				glog.Exitf("Error setting aircon: %v", err)
@@ -115,7 +305,31 @@ func main() {
 			if err := d.GetSensorInfo(); err != nil {
 				glog.Exitf("Error getting aircon data: %v", err)
 			}
-			fmt.Printf("New values %s:\n%s\n\n", a, d)
+			printf("New values %s:\n%s\n\n", a, d)
+		}
+		devices = append(devices, d)
+	}
+	if sleep.set {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		printf("Running sleep timer for %s\n", sleepTimer.Duration)
+		var wg sync.WaitGroup
+		for _, d := range devices {
+			wg.Add(1)
+			go func(d *daikin.Daikin) {
+				defer wg.Done()
+				if err := d.SetSleepTimer(ctx, sleepTimer); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %s sleep timer failed: %v\n", d.Address, err)
+				}
+			}(d)
+		}
+		wg.Wait()
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(devices); err != nil {
+			glog.Exit(err)
 		}
 	}
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+// setback handles the setback subcommand, configuring the devices with the
+// day or night set temperature for the current time. It is intended to be
+// run periodically, eg from cron.
+func setback(args []string) error {
+	fs := flag.NewFlagSet("setback", flag.ContinueOnError)
+	var addrs addressList
+	fs.Var(&addrs, "address", "Use device at specific address, may be repeated")
+	iface := fs.String("interface", "", "Interface to scan on, or a comma separated list")
+	day := fs.Float64("day", 22, "Daytime temperature")
+	night := fs.Float64("night", 18, "Night temperature")
+	start := fs.Int("start", 22, "Hour the night starts, 0-23")
+	end := fs.Int("end", 6, "Hour the night ends, 0-23")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ns := daikin.NightSetback{
+		DaytimeTemp: daikin.Temperature(*day),
+		NightTemp:   daikin.Temperature(*night),
+		StartHour:   *start,
+		EndHour:     *end,
+	}
+
+	opts := []daikin.Option{daikin.InterfaceOption(strings.Split(*iface, ",")...)}
+	for _, a := range addrs {
+		opts = append(opts, daikin.AddressTokenOption(a, ""))
+	}
+	n, err := daikin.NewNetwork(opts...)
+	if err != nil {
+		return err
+	}
+	if err := n.Discover(); err != nil {
+		return err
+	}
+	now := time.Now()
+	failed := false
+	for _, d := range n.DeviceList() {
+		a := d.Address
+		if err := d.ApplySetback(context.Background(), ns, now); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s setback failed: %v\n", a, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s: set to %s\n", a, &d.ControlInfo.Temperature)
+	}
+	if failed {
+		return fmt.Errorf("setback failed for some devices")
+	}
+	return nil
+}
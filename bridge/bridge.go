@@ -0,0 +1,183 @@
+// Package bridge serves Daikin units over a JSON HTTP API, for services
+// which cannot use the daikin package directly.
+//
+// The routes are:
+//
+//	GET /devices                 the last known state of all units
+//	GET /devices/{id}/control    the current control settings of a unit
+//	PUT /devices/{id}/control    change the control settings of a unit
+//	GET /devices/{id}/sensor     the current sensor values of a unit
+//
+// Units are identified by their key in the devices map. Fields omitted from
+// a PUT body are left unchanged.
+package bridge
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+// Server is an http.Handler serving the bridge API.
+type Server struct {
+	token   string
+	devices map[string]*daikin.Daikin
+	mux     *http.ServeMux
+	logger  *slog.Logger
+}
+
+// TokenOption requires requests to authenticate with the given bearer
+// token, in an "Authorization: Bearer <token>" header.
+func TokenOption(token string) func(*Server) {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// LoggerOption configures the logger for errors. By default slog.Default()
+// is used.
+func LoggerOption(l *slog.Logger) func(*Server) {
+	return func(s *Server) {
+		s.logger = l
+	}
+}
+
+// NewServer returns a handler serving the bridge API for the devices, keyed
+// by their id.
+func NewServer(devices map[string]*daikin.Daikin, opts ...func(*Server)) http.Handler {
+	s := &Server{
+		devices: devices,
+		mux:     http.NewServeMux(),
+		logger:  slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mux.HandleFunc("GET /devices", s.listDevices)
+	s.mux.HandleFunc("GET /devices/{id}/control", s.withDevice(s.getControl))
+	s.mux.HandleFunc("PUT /devices/{id}/control", s.withDevice(s.putControl))
+	s.mux.HandleFunc("GET /devices/{id}/sensor", s.withDevice(s.getSensor))
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := []byte(r.Header.Get("Authorization"))
+	if s.token != "" && subtle.ConstantTimeCompare(auth, []byte("Bearer "+s.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, errors.New("invalid or missing token"))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// withDevice looks up the device for the request's id, and calls h with it.
+func (s *Server) withDevice(h func(http.ResponseWriter, *http.Request, *daikin.Daikin)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d, ok := s.devices[r.PathValue("id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, errors.New("unknown device"))
+			return
+		}
+		h(w, r, d)
+	}
+}
+
+func (s *Server) listDevices(w http.ResponseWriter, r *http.Request) {
+	devices := make(map[string]json.RawMessage, len(s.devices))
+	for id, d := range s.devices {
+		err := d.WithLock(func() error {
+			b, err := json.Marshal(d)
+			devices[id] = b
+			return err
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	s.writeJSON(w, devices)
+}
+
+func (s *Server) getControl(w http.ResponseWriter, r *http.Request, d *daikin.Daikin) {
+	var ci daikin.ControlInfo
+	err := d.WithLock(func() error {
+		if err := d.GetControlInfoContext(r.Context()); err != nil {
+			return err
+		}
+		ci = *d.ControlInfo
+		return nil
+	})
+	if err != nil {
+		writeDeviceError(w, err)
+		return
+	}
+	s.writeJSON(w, ci)
+}
+
+func (s *Server) putControl(w http.ResponseWriter, r *http.Request, d *daikin.Daikin) {
+	var decodeErr error
+	ci, err := d.UpdateControlInfo(r.Context(), func(c *daikin.ControlInfo) error {
+		decodeErr = json.NewDecoder(r.Body).Decode(c)
+		return decodeErr
+	})
+	switch {
+	case decodeErr != nil:
+		writeError(w, http.StatusBadRequest, decodeErr)
+	case err != nil:
+		writeDeviceError(w, err)
+	default:
+		s.writeJSON(w, ci)
+	}
+}
+
+func (s *Server) getSensor(w http.ResponseWriter, r *http.Request, d *daikin.Daikin) {
+	var si daikin.SensorInfo
+	err := d.WithLock(func() error {
+		if err := d.GetSensorInfoContext(r.Context()); err != nil {
+			return err
+		}
+		si = *d.SensorInfo
+		return nil
+	})
+	if err != nil {
+		writeDeviceError(w, err)
+		return
+	}
+	s.writeJSON(w, si)
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.logger.Error("writing response failed", "err", err)
+	}
+}
+
+// writeDeviceError writes err from a device request, as a client error if
+// the unit rejected the request, or a bad gateway error otherwise.
+func writeDeviceError(w http.ResponseWriter, err error) {
+	var (
+		devErr   *daikin.DeviceError
+		rangeErr *daikin.ErrTemperatureOutOfRange
+		unsupErr *daikin.ErrUnsupported
+	)
+	switch {
+	case errors.As(err, &devErr), errors.As(err, &rangeErr):
+		writeError(w, http.StatusBadRequest, err)
+	case errors.As(err, &unsupErr):
+		writeError(w, http.StatusNotImplemented, err)
+	default:
+		writeError(w, http.StatusBadGateway, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
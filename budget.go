@@ -0,0 +1,37 @@
+package daikin
+
+import "context"
+
+// EnergyBudget is a limit on the energy consumption of a unit.
+type EnergyBudget struct {
+	// WeeklyKWh is the energy allowed over the past week, in kWh.
+	WeeklyKWh float64
+}
+
+// CheckBudget fetches the daily energy consumption for the past week, and
+// returns the energy in kWh remaining in the budget, and whether the
+// budget has been exceeded. remaining is negative if exceeded.
+func (d *Daikin) CheckBudget(ctx context.Context, budget EnergyBudget) (remaining float64, exceeded bool, err error) {
+	if err := d.GetWeekPowerContext(ctx); err != nil {
+		return 0, false, err
+	}
+	remaining = budget.WeeklyKWh - d.WeekPowerInfo.TotalWeek()
+	return remaining, remaining < 0, nil
+}
+
+// EnforceBudget turns the unit off if the budget has been exceeded. Other
+// control settings are left unchanged.
+func (d *Daikin) EnforceBudget(ctx context.Context, budget EnergyBudget) error {
+	_, exceeded, err := d.CheckBudget(ctx, budget)
+	if err != nil || !exceeded {
+		return err
+	}
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return err
+	}
+	if d.ControlInfo.Power == PowerOff {
+		return nil
+	}
+	d.ControlInfo.Power = PowerOff
+	return d.SetControlInfoContext(ctx)
+}
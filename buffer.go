@@ -0,0 +1,124 @@
+package daikin
+
+import (
+	"sync"
+	"time"
+)
+
+// RingBuffer holds the most recent sensor readings of a unit, for simple
+// trend analysis. It is safe for concurrent use.
+type RingBuffer struct {
+	// Size is the number of readings held. Older readings are discarded.
+	Size int
+
+	mu    sync.Mutex
+	snaps []SensorSnapshot
+	// next is the index of the slot for the next reading, once full.
+	next int
+}
+
+// AttachBuffer records the sensor readings of the unit in buf, each time
+// they are fetched.
+func (d *Daikin) AttachBuffer(buf *RingBuffer) {
+	d.hooks.mu.Lock()
+	defer d.hooks.mu.Unlock()
+	d.hooks.buffers = append(d.hooks.buffers, buf)
+}
+
+// Add records a reading, discarding the oldest if the buffer is full.
+func (b *RingBuffer) Add(s SensorSnapshot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.Size < 1 {
+		return
+	}
+	if len(b.snaps) < b.Size {
+		b.snaps = append(b.snaps, s)
+		return
+	}
+	b.snaps[b.next] = s
+	b.next = (b.next + 1) % len(b.snaps)
+}
+
+// Slice returns a copy of the readings, oldest first.
+func (b *RingBuffer) Slice() []SensorSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append(append([]SensorSnapshot(nil), b.snaps[b.next:]...), b.snaps[:b.next]...)
+}
+
+// MinTemp returns the lowest indoor temperature of the readings, or zero
+// if there are none.
+func (b *RingBuffer) MinTemp() Temperature {
+	return b.extreme(func(t, m Temperature) bool { return t < m })
+}
+
+// MaxTemp returns the highest indoor temperature of the readings, or zero
+// if there are none.
+func (b *RingBuffer) MaxTemp() Temperature {
+	return b.extreme(func(t, m Temperature) bool { return t > m })
+}
+
+// extreme returns the indoor temperature which beats all others.
+func (b *RingBuffer) extreme(beats func(t, m Temperature) bool) Temperature {
+	snaps := b.Slice()
+	if len(snaps) == 0 {
+		return 0
+	}
+	m := snaps[0].HomeTemperature
+	for _, s := range snaps[1:] {
+		if beats(s.HomeTemperature, m) {
+			m = s.HomeTemperature
+		}
+	}
+	return m
+}
+
+// Avg returns the mean indoor and outdoor temperature and humidity of the
+// readings. Readings without humidity are excluded from its mean, which
+// is -1 if none have it. It returns the zero SensorInfo if there are no
+// readings.
+func (b *RingBuffer) Avg() SensorInfo {
+	snaps := b.Slice()
+	if len(snaps) == 0 {
+		return SensorInfo{}
+	}
+	var home, outside Temperature
+	var humidity, humidityCount int
+	for _, s := range snaps {
+		home += s.HomeTemperature
+		outside += s.OutsideTemperature
+		if s.SensorInfo.Humidity >= 0 {
+			humidity += int(s.SensorInfo.Humidity)
+			humidityCount++
+		}
+	}
+	avg := SensorInfo{
+		HomeTemperature:    home / Temperature(len(snaps)),
+		OutsideTemperature: outside / Temperature(len(snaps)),
+		Humidity:           -1,
+	}
+	if humidityCount > 0 {
+		avg.Humidity = Humidity(humidity / humidityCount)
+	}
+	return avg
+}
+
+// record adds the fetched sensor values to the attached buffers.
+func (d *Daikin) record(s *SensorInfo) {
+	d.hooks.mu.Lock()
+	bufs := d.hooks.buffers
+	d.hooks.mu.Unlock()
+	if len(bufs) == 0 {
+		return
+	}
+	snap := SensorSnapshot{
+		SensorInfo:  *s,
+		ControlInfo: d.Snapshot(),
+		Address:     d.Address,
+		Timestamp:   time.Now(),
+	}
+	for _, b := range bufs {
+		b.Add(snap)
+	}
+}
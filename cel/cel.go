@@ -0,0 +1,99 @@
+// Package cel evaluates rules written in the Common Expression Language
+// (CEL) against the state of Daikin units, so rules can be loaded at run
+// time without recompiling.
+//
+// Expressions may use the variables sensor and control, maps holding the
+// fields of daikin.SensorInfo and daikin.ControlInfo keyed by their JSON
+// names, eg:
+//
+//	sensor.home_temperature > 28.0 && control.mode != "Cool"
+//
+// Settings are their names, eg "Cool", and numbers may be compared with
+// integer or floating point values.
+package cel
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/buxtronix/go-daikin"
+	gocel "github.com/google/cel-go/cel"
+)
+
+// env is the CEL environment expressions are compiled in.
+var env *gocel.Env
+
+func init() {
+	var err error
+	env, err = gocel.NewEnv(
+		gocel.Variable("sensor", gocel.MapType(gocel.StringType, gocel.DynType)),
+		gocel.Variable("control", gocel.MapType(gocel.StringType, gocel.DynType)),
+		gocel.CrossTypeNumericComparisons(true),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// CelRule is a rule whose condition is a CEL expression.
+type CelRule struct {
+	// Condition is the CEL expression the rule was created with.
+	Condition string
+	// Action are the control settings to apply when the condition holds.
+	Action daikin.ControlInfo
+
+	prg gocel.Program
+}
+
+// NewRule returns a rule applying the action when the CEL expression
+// condition is true. The expression is compiled, and must evaluate to a
+// bool.
+func NewRule(condition string, action daikin.ControlInfo) (*CelRule, error) {
+	ast, iss := env.Compile(condition)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	// Map values are dynamically typed, so may only be checked when run.
+	if t := ast.OutputType(); t != gocel.BoolType && t != gocel.DynType {
+		return nil, fmt.Errorf("condition has type %s, want bool", ast.OutputType())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	return &CelRule{Condition: condition, Action: action, prg: prg}, nil
+}
+
+// toMap returns the JSON fields of v as a map.
+func toMap(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	return m, json.Unmarshal(b, &m)
+}
+
+// Evaluate returns whether the condition holds for the given state.
+func (r *CelRule) Evaluate(si daikin.SensorInfo, ci daikin.ControlInfo) (bool, error) {
+	sensor, err := toMap(si)
+	if err != nil {
+		return false, err
+	}
+	control, err := toMap(ci)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := r.prg.Eval(map[string]interface{}{
+		"sensor":  sensor,
+		"control": control,
+	})
+	if err != nil {
+		return false, err
+	}
+	b, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("condition evaluated to %v, want bool", out)
+	}
+	return b, nil
+}
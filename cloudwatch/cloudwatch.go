@@ -0,0 +1,148 @@
+// Package cloudwatch publishes the state of Daikin units as custom AWS
+// CloudWatch metrics.
+//
+// The metrics are IndoorTemperature and OutdoorTemperature in Celsius,
+// Humidity in percent and PowerState (0 off, 1 on), each with a dimension
+// holding the name of the unit, or its address if unnamed.
+package cloudwatch
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/buxtronix/go-daikin"
+)
+
+// maxDatums is the maximum number of metrics sent in each request.
+const maxDatums = 20
+
+// Publisher publishes the metrics of a set of units.
+type Publisher struct {
+	client        *cloudwatch.Client
+	namespace     string
+	dimensionName string
+	devices       []*daikin.Daikin
+	interval      time.Duration
+	logger        *slog.Logger
+}
+
+// FlushIntervalOption configures the interval Run publishes at. The
+// default is one minute, which is kept if d is not positive.
+func FlushIntervalOption(d time.Duration) func(*Publisher) {
+	return func(p *Publisher) {
+		if d > 0 {
+			p.interval = d
+		}
+	}
+}
+
+// LoggerOption configures the logger for errors from Run. By default
+// slog.Default() is used.
+func LoggerOption(l *slog.Logger) func(*Publisher) {
+	return func(p *Publisher) {
+		p.logger = l
+	}
+}
+
+// NewPublisher returns a Publisher for the devices, sending metrics to the
+// given namespace with the AWS configuration cfg. Units are identified by
+// the dimension dimensionName.
+func NewPublisher(cfg aws.Config, namespace, dimensionName string, devices []*daikin.Daikin, opts ...func(*Publisher)) *Publisher {
+	p := &Publisher{
+		client:        cloudwatch.NewFromConfig(cfg),
+		namespace:     namespace,
+		dimensionName: dimensionName,
+		devices:       devices,
+		interval:      time.Minute,
+		logger:        slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// datums fetches the state of the unit, and returns its metrics.
+func (p *Publisher) datums(ctx context.Context, d *daikin.Daikin) ([]types.MetricDatum, error) {
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return nil, err
+	}
+	if err := d.GetSensorInfoContext(ctx); err != nil {
+		return nil, err
+	}
+	name := d.Name.String()
+	if name == "" {
+		name = d.Address
+	}
+	now := time.Now()
+	datum := func(metric string, v float64, unit types.StandardUnit) types.MetricDatum {
+		return types.MetricDatum{
+			MetricName: aws.String(metric),
+			Dimensions: []types.Dimension{{Name: aws.String(p.dimensionName), Value: aws.String(name)}},
+			Timestamp:  aws.Time(now),
+			Value:      aws.Float64(v),
+			Unit:       unit,
+		}
+	}
+	s, ci := d.SensorInfo, d.ControlInfo
+	datums := []types.MetricDatum{
+		datum("IndoorTemperature", float64(s.HomeTemperature), types.StandardUnitNone),
+		datum("OutdoorTemperature", float64(s.OutsideTemperature), types.StandardUnitNone),
+		datum("PowerState", float64(ci.Power), types.StandardUnitNone),
+	}
+	if s.Humidity >= 0 {
+		datums = append(datums, datum("Humidity", float64(s.Humidity), types.StandardUnitPercent))
+	}
+	return datums, nil
+}
+
+// Publish fetches the state of all units and publishes their metrics. If
+// any units could not be queried, the others are published and their
+// errors returned in a daikin.GroupError.
+func (p *Publisher) Publish(ctx context.Context) error {
+	var datums []types.MetricDatum
+	errs := daikin.GroupError{}
+	for _, d := range p.devices {
+		ds, err := p.datums(ctx, d)
+		if err != nil {
+			errs[d.Address] = err
+			continue
+		}
+		datums = append(datums, ds...)
+	}
+	for len(datums) > 0 {
+		n := min(len(datums), maxDatums)
+		if _, err := p.client.PutMetricData(ctx, &cloudwatch.PutMetricDataInput{
+			Namespace:  aws.String(p.namespace),
+			MetricData: datums[:n],
+		}); err != nil {
+			return err
+		}
+		datums = datums[n:]
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Run publishes immediately and then every flush interval, until ctx is
+// done. Errors are logged, and publishing continues.
+func (p *Publisher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Publish(ctx); err != nil {
+			p.logger.Error("publishing to CloudWatch failed", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
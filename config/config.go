@@ -0,0 +1,95 @@
+// Package config loads Daikin device configuration from a file, for setups
+// with multiple units. Files may be YAML or TOML.
+//
+// An example YAML configuration:
+//
+//	devices:
+//	  - name: livingroom
+//	    address: 192.168.1.50
+//	    token: abc
+//
+// The equivalent TOML configuration:
+//
+//	[[devices]]
+//	name = "livingroom"
+//	address = "192.168.1.50"
+//	token = "abc"
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buxtronix/go-daikin"
+	"gopkg.in/yaml.v3"
+)
+
+// Device is the configuration of a single unit.
+type Device struct {
+	// Name is the human-readable name of the unit.
+	Name string `yaml:"name" toml:"name"`
+	// Address is the IP address of the unit.
+	Address string `yaml:"address" toml:"address"`
+	// Token is the authentication token, for adapters which require one.
+	Token string `yaml:"token" toml:"token"`
+}
+
+// Config is the configuration of a set of units.
+type Config struct {
+	// Devices are the configured units.
+	Devices []Device `yaml:"devices" toml:"devices"`
+}
+
+// LoadFile loads the configuration from a file. Files with a .toml
+// extension are parsed as TOML, and others as YAML.
+func LoadFile(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		err = toml.Unmarshal(b, c)
+	} else {
+		err = yaml.Unmarshal(b, c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+	for i, d := range c.Devices {
+		if d.Address == "" {
+			return nil, fmt.Errorf("%s: device %d has no address", path, i)
+		}
+	}
+	return c, nil
+}
+
+// Options returns the options to pass to daikin.NewNetwork to add the
+// configured devices. Discovery is disabled when any are configured. If a
+// device was already added at the same address, eg by
+// daikin.AddressTokenOption, the configured token and name are applied to
+// it.
+func (c *Config) Options() []daikin.Option {
+	var opts []daikin.Option
+	for _, d := range c.Devices {
+		var devOpts []daikin.DeviceOption
+		if d.Token != "" {
+			devOpts = append(devOpts, daikin.WithToken(d.Token))
+		}
+		if d.Name != "" {
+			devOpts = append(devOpts, daikin.WithName(d.Name))
+		}
+		opts = append(opts, func(n *daikin.DaikinNetwork) {
+			if dev, ok := n.DeviceByAddress(d.Address); ok {
+				dev.Apply(devOpts...)
+			} else if _, err := n.AddDevice(d.Address, devOpts...); err != nil {
+				n.Logger().Warn("can't add configured device", "address", d.Address, "err", err)
+			}
+			n.PollCount = 0
+		})
+	}
+	return opts
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+func TestOptions(t *testing.T) {
+	c := &Config{Devices: []Device{
+		{Name: "lounge", Address: "192.0.2.1", Token: "abc"},
+		{Name: "bedroom", Address: "192.0.2.2"},
+	}}
+	tests := []struct {
+		name string
+		opts []daikin.Option
+	}{
+		{name: "config only"},
+		// Config devices are added after --address ones, and take
+		// precedence.
+		{name: "address added first", opts: []daikin.Option{
+			daikin.AddressTokenOption("192.0.2.1", ""),
+			daikin.AddressTokenOption("192.0.2.2", "def"),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := daikin.NewNetwork(append(tt.opts, c.Options()...)...)
+			if err != nil {
+				t.Fatalf("NewNetwork() = %v", err)
+			}
+			if got := len(n.DeviceList()); got != 2 {
+				t.Errorf("got %d devices, want 2", got)
+			}
+			d, ok := n.DeviceByAddress("192.0.2.1")
+			if !ok {
+				t.Fatal("192.0.2.1 not added")
+			}
+			if d.Token != "abc" || d.Name != "lounge" {
+				t.Errorf("192.0.2.1 token=%q name=%q, want token=%q name=%q", d.Token, d.Name, "abc", "lounge")
+			}
+			d, ok = n.DeviceByAddress("192.0.2.2")
+			if !ok {
+				t.Fatal("192.0.2.2 not added")
+			}
+			if d.Name != "bedroom" {
+				t.Errorf("192.0.2.2 name=%q, want %q", d.Name, "bedroom")
+			}
+			if n.PollCount != 0 {
+				t.Errorf("PollCount = %d, want 0", n.PollCount)
+			}
+		})
+	}
+}
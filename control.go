@@ -0,0 +1,135 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Snapshot returns a copy of the current control settings of the unit, as
+// last fetched or set. It is the zero ControlInfo if none is known.
+func (d *Daikin) Snapshot() ControlInfo {
+	if d.ControlInfo == nil {
+		return ControlInfo{}
+	}
+	return *d.ControlInfo
+}
+
+// WithLock calls fn with the unit locked, so that a sequence of requests
+// and reads of the fetched info is not interleaved with those of other
+// users of the unit, eg several servers sharing it. UpdateControlInfo and
+// FetchSnapshot also hold the lock, so fn must not call them.
+func (d *Daikin) WithLock(fn func() error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return fn()
+}
+
+// UpdateControlInfo fetches the current control settings of the unit,
+// calls fn to modify a copy of them, and configures the unit with the
+// result. It returns a copy of the settings then fetched from the unit. If
+// fn returns
+// an error, the unit is not configured and the error is returned. The unit
+// is locked throughout, as for WithLock.
+func (d *Daikin) UpdateControlInfo(ctx context.Context, fn func(c *ControlInfo) error) (ControlInfo, error) {
+	var updated ControlInfo
+	err := d.WithLock(func() error {
+		if err := d.GetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		c := *d.ControlInfo
+		if err := fn(&c); err != nil {
+			return err
+		}
+		d.ControlInfo = &c
+		if err := d.SetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		if err := d.GetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		updated = *d.ControlInfo
+		return nil
+	})
+	return updated, err
+}
+
+// RestoreSnapshot configures the unit with the control settings from a
+// previous Snapshot.
+func (d *Daikin) RestoreSnapshot(ctx context.Context, snap ControlInfo) error {
+	d.ControlInfo = &snap
+	return d.SetControlInfoContext(ctx)
+}
+
+// ControlChange is a control setting that differs between two states.
+type ControlChange struct {
+	// Field is the name of the ControlInfo field that changed.
+	Field string
+	// From is the original value.
+	From string
+	// To is the new value.
+	To string
+}
+
+func (c ControlChange) String() string {
+	return fmt.Sprintf("%s %s→%s", c.Field, c.From, c.To)
+}
+
+// Diff returns the control settings that differ between a and b, in field
+// order.
+func Diff(a, b ControlInfo) []ControlChange {
+	var changes []ControlChange
+	add := func(field string, from, to fmt.Stringer) {
+		if f, t := from.String(), to.String(); f != t {
+			changes = append(changes, ControlChange{Field: field, From: f, To: t})
+		}
+	}
+	add("Power", &a.Power, &b.Power)
+	add("Mode", &a.Mode, &b.Mode)
+	add("Temperature", &a.Temperature, &b.Temperature)
+	add("Humidity", &a.Humidity, &b.Humidity)
+	add("Fan", &a.Fan, &b.Fan)
+	add("FanDir", &a.FanDir, &b.FanDir)
+	addBool := func(field string, from, to bool) {
+		if from != to {
+			changes = append(changes, ControlChange{Field: field, From: strconv.FormatBool(from), To: strconv.FormatBool(to)})
+		}
+	}
+	addBool("Powerful", a.Powerful, b.Powerful)
+	addBool("Eco", a.Eco, b.Eco)
+	addBool("Streamer", a.Streamer, b.Streamer)
+	addBool("ComfortAirflow", a.ComfortAirflow, b.ComfortAirflow)
+	addBool("IntelligentEye", a.IntelligentEye, b.IntelligentEye)
+	return changes
+}
+
+// SetControlInfoIfChanged fetches the current control settings from the
+// unit, and configures the unit with desired only if they differ. It
+// returns whether the settings were sent to the unit.
+func (d *Daikin) SetControlInfoIfChanged(ctx context.Context, desired ControlInfo) (bool, error) {
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return false, err
+	}
+	if len(Diff(*d.ControlInfo, desired)) == 0 {
+		return false, nil
+	}
+	d.ControlInfo = &desired
+	if err := d.SetControlInfoContext(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PowerToggle fetches the current control settings from the unit, and
+// turns it off if it is on, or on if it is off.
+func (d *Daikin) PowerToggle(ctx context.Context) error {
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return err
+	}
+	if d.ControlInfo.Power == PowerOn {
+		d.ControlInfo.Power = PowerOff
+	} else {
+		d.ControlInfo.Power = PowerOn
+	}
+	return d.SetControlInfoContext(ctx)
+}
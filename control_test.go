@@ -0,0 +1,119 @@
+package daikin_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/buxtronix/go-daikin"
+	"github.com/buxtronix/go-daikin/daikintest"
+)
+
+// TestUpdateControlInfoConcurrent checks that concurrent updates are
+// serialised, so none are lost.
+func TestUpdateControlInfoConcurrent(t *testing.T) {
+	m := daikintest.NewMockDevice(t)
+	d := m.Device()
+	const n = 10
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := d.UpdateControlInfo(context.Background(), func(c *daikin.ControlInfo) error {
+				c.Temperature += 0.5
+				return nil
+			})
+			if err != nil {
+				t.Errorf("UpdateControlInfo() = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got, want := m.State().Temperature, daikin.Temperature(22+n*0.5); got != want {
+		t.Errorf("unit temperature = %v, want %v", got, want)
+	}
+}
+
+func TestUpdateControlInfoError(t *testing.T) {
+	m := daikintest.NewMockDevice(t)
+	d := m.Device()
+	errBad := errors.New("bad input")
+	_, err := d.UpdateControlInfo(context.Background(), func(c *daikin.ControlInfo) error {
+		c.Power = daikin.PowerOn
+		return errBad
+	})
+	if !errors.Is(err, errBad) {
+		t.Errorf("UpdateControlInfo() = %v, want %v", err, errBad)
+	}
+	if got := m.State().Power; got != daikin.PowerOff {
+		t.Errorf("unit power = %v, want unchanged %v", got, daikin.PowerOff)
+	}
+}
+
+// TestChangeHooks checks that the change callbacks are called only when a
+// fetch finds different values, with the previous and current values.
+func TestChangeHooks(t *testing.T) {
+	tests := []struct {
+		name string
+		// change modifies the unit's state before the fetch, if set.
+		change     func(m *daikintest.MockDevice)
+		wantState  int
+		wantSensor int
+	}{
+		{name: "first fetch"},
+		{name: "unchanged"},
+		{
+			name: "control changed",
+			change: func(m *daikintest.MockDevice) {
+				c := m.State()
+				c.Temperature += 1
+				m.SetState(c)
+			},
+			wantState: 1,
+		},
+		{
+			name: "sensor changed",
+			change: func(m *daikintest.MockDevice) {
+				s := m.SensorState()
+				s.HomeTemperature += 0.5
+				m.SetSensorState(s)
+			},
+			wantSensor: 1,
+		},
+		{name: "unchanged after change"},
+	}
+	m := daikintest.NewMockDevice(t)
+	d := m.Device()
+	var stateCalls, sensorCalls int
+	d.OnStateChange(func(_ *daikin.Daikin, prev, curr daikin.ControlInfo) {
+		stateCalls++
+		if curr.Temperature != prev.Temperature+1 {
+			t.Errorf("OnStateChange() temperature %v -> %v, want increase of 1", prev.Temperature, curr.Temperature)
+		}
+	})
+	d.OnSensorChange(func(_ *daikin.Daikin, prev, curr daikin.SensorInfo) {
+		sensorCalls++
+		if curr.HomeTemperature != prev.HomeTemperature+0.5 {
+			t.Errorf("OnSensorChange() temperature %v -> %v, want increase of 0.5", prev.HomeTemperature, curr.HomeTemperature)
+		}
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.change != nil {
+				tt.change(m)
+			}
+			stateCalls, sensorCalls = 0, 0
+			if err := d.GetControlInfo(); err != nil {
+				t.Fatalf("GetControlInfo() = %v", err)
+			}
+			if err := d.GetSensorInfo(); err != nil {
+				t.Fatalf("GetSensorInfo() = %v", err)
+			}
+			if stateCalls != tt.wantState || sensorCalls != tt.wantSensor {
+				t.Errorf("callbacks called state=%d sensor=%d, want state=%d sensor=%d", stateCalls, sensorCalls, tt.wantState, tt.wantSensor)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package daikin
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// csvHeader is the header row of the CSV format.
+var csvHeader = []string{"address", "name", "power", "mode", "temperature", "humidity", "fan", "fandir"}
+
+// ExportCSV writes the address, name and control settings of the devices
+// as CSV, one row per device after a header row. Settings are written by
+// name, eg mode "Heat". They are left empty for devices whose control info
+// has not been fetched.
+func ExportCSV(w io.Writer, devices []*Daikin) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, d := range devices {
+		row := []string{d.Address, d.Name.String(), "", "", "", "", "", ""}
+		if c := d.ControlInfo; c != nil {
+			copy(row[2:], []string{c.Power.String(), c.Mode.String(), c.Temperature.celsius(), c.Humidity.String(), c.Fan.String(), c.FanDir.String()})
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportCSV reads devices written by ExportCSV. Settings may be given by
+// name or protocol value. The devices have ControlInfo populated, or nil
+// if the settings are empty, but nothing is sent to them; the caller may
+// configure them with SetControlInfo.
+func ImportCSV(r io.Reader) ([]*Daikin, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, &ParseError{Err: err}
+	}
+	for i, h := range header {
+		if !strings.EqualFold(strings.TrimSpace(h), csvHeader[i]) {
+			return nil, &ParseError{Err: fmt.Errorf("have column %q, want %q", h, csvHeader[i])}
+		}
+	}
+	var devices []*Daikin
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return devices, nil
+		}
+		if err != nil {
+			return nil, &ParseError{Err: err}
+		}
+		d := &Daikin{Address: row[0], Name: Name(row[1])}
+		if strings.Join(row[2:], "") != "" {
+			c := &ControlInfo{}
+			if err := c.parseCSV(row[2:]); err != nil {
+				return nil, err
+			}
+			d.ControlInfo = c
+		}
+		devices = append(devices, d)
+	}
+}
+
+// parseCSV parses the control settings columns of a CSV row.
+func (c *ControlInfo) parseCSV(row []string) error {
+	var err error
+	if c.Power, err = ParsePower(row[0]); err != nil {
+		return &ParseError{Key: "power", Value: row[0], Err: err}
+	}
+	if c.Mode, err = ParseMode(row[1]); err != nil {
+		return &ParseError{Key: "mode", Value: row[1], Err: err}
+	}
+	if err = c.Temperature.decode(row[2]); err != nil {
+		return &ParseError{Key: "temperature", Value: row[2], Err: err}
+	}
+	if err = c.Humidity.decode(row[3]); err != nil {
+		return &ParseError{Key: "humidity", Value: row[3], Err: err}
+	}
+	if c.Fan, err = ParseFan(row[4]); err != nil {
+		return &ParseError{Key: "fan", Value: row[4], Err: err}
+	}
+	if c.FanDir, err = ParseFanDir(row[5]); err != nil {
+		return &ParseError{Key: "fandir", Value: row[5], Err: err}
+	}
+	return nil
+}
@@ -4,13 +4,21 @@
 package daikin
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log/slog"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
 const (
@@ -28,6 +36,10 @@ const (
 	uriGetScdlTimer    = "/aircon/get_scdltimer"
 	uriGetNotify       = "/aircon/get_notify"
 	uriSetControlInfo  = "/aircon/set_control_info"
+	uriSetTimer        = "/aircon/set_timer"
+	uriSetName         = "/common/set_name"
+	uriSetProgram      = "/aircon/set_program"
+	uriSetScdlTimer    = "/aircon/set_scdltimer"
 )
 
 /*
@@ -46,6 +58,9 @@ const (
 	returnBad = "PARAM NG"
 )
 
+// tokenHeader is the request header carrying the authentication token.
+const tokenHeader = "X-Daikin-uuid"
+
 // Power represents the power status of the unit (off/on).
 type Power int
 
@@ -71,7 +86,7 @@ func (p *Power) decode(s string) error {
 	case "1":
 		*p = Power(PowerOn)
 	default:
-		return fmt.Errorf("unknown pwr value: %s", s)
+		return &ErrUnknownValue{Type: "pow", Value: s}
 	}
 	return nil
 }
@@ -137,7 +152,7 @@ func (m *Mode) decode(s string) error {
 	case "7":
 		*m = Mode(ModeAuto7)
 	default:
-		return fmt.Errorf("unknown mode value: %s", s)
+		return &ErrUnknownValue{Type: "mode", Value: s}
 	}
 	return nil
 }
@@ -187,7 +202,7 @@ func (f *Fan) decode(s string) error {
 	case "7":
 		*f = Fan(Fan5)
 	default:
-		return fmt.Errorf("unknown pwr value: %s", s)
+		return &ErrUnknownValue{Type: "f_rate", Value: s}
 	}
 	return nil
 }
@@ -200,6 +215,24 @@ func (f *Fan) String() string {
 	return v
 }
 
+// fanLevels maps fan settings to their numeric level.
+var fanLevels = map[Fan]int{
+	FanAuto:   -1,
+	FanSilent: 0,
+	Fan1:      1,
+	Fan2:      2,
+	Fan3:      3,
+	Fan4:      4,
+	Fan5:      5,
+}
+
+// Level returns the fan setting as a number for metrics: 1-5 for the
+// speeds, 0 for silent and -1 for auto. ok is false for unknown settings.
+func (f Fan) Level() (level int, ok bool) {
+	level, ok = fanLevels[f]
+	return level, ok
+}
+
 // FanDir is the louvre swing setting of the Daikin unit.
 type FanDir int
 
@@ -209,6 +242,10 @@ const (
 	FanDirVertical   FanDir = 1
 	FanDirHorizontal FanDir = 2
 	FanDirBoth       FanDir = 3
+	// Fixed louvre positions, as supported by the FTXZ series.
+	FanDirFixedLow  FanDir = 8
+	FanDirFixedMid  FanDir = 9
+	FanDirFixedHigh FanDir = 10
 )
 
 var fanDirMap = map[FanDir]string{
@@ -216,6 +253,14 @@ var fanDirMap = map[FanDir]string{
 	FanDirVertical:   "Vertical",
 	FanDirHorizontal: "Horizontal",
 	FanDirBoth:       "Both",
+	FanDirFixedLow:   "Fixed Low",
+	FanDirFixedMid:   "Fixed Mid",
+	FanDirFixedHigh:  "Fixed High",
+}
+
+// fixed returns whether f is a fixed louvre position.
+func (f FanDir) fixed() bool {
+	return f >= FanDirFixedLow
 }
 
 func (f *FanDir) setUrlValues(v url.Values) {
@@ -224,12 +269,9 @@ func (f *FanDir) setUrlValues(v url.Values) {
 
 func (f *FanDir) decode(s string) error {
 	v, err := strconv.Atoi(s)
-	if err != nil {
-		return fmt.Errorf("invalid f_dir value: %s (err=%v)", s, err)
-	}
 	fd := FanDir(v)
-	if _, ok := fanDirMap[fd]; !ok {
-		return fmt.Errorf("unknown f_dir value: %s", s)
+	if _, ok := fanDirMap[fd]; err != nil || !ok {
+		return &ErrUnknownValue{Type: "f_dir", Value: s}
 	}
 	*f = fd
 	return nil
@@ -246,21 +288,75 @@ func (f *FanDir) String() string {
 // Temperature is the set temperature of the Daikin unit, in Celcius.
 type Temperature float64
 
+// Unit is a temperature display unit.
+type Unit int
+
+// Supported temperature display units.
+const (
+	Celsius Unit = iota
+	Fahrenheit
+)
+
+// DefaultUnit is the unit Temperature values are displayed in by String.
+// Values sent to and received from units are always in Celsius.
+var DefaultUnit = Celsius
+
+// TemperatureFromFahrenheit returns the Temperature for a value in
+// Fahrenheit.
+func TemperatureFromFahrenheit(f float64) Temperature {
+	return Temperature((f - 32) * 5 / 9)
+}
+
+// ToFahrenheit returns the temperature in Fahrenheit.
+func (t Temperature) ToFahrenheit() float64 {
+	return float64(t)*9/5 + 32
+}
+
+// StringF returns the temperature formatted in Fahrenheit.
+func (t Temperature) StringF() string {
+	return strconv.FormatFloat(t.ToFahrenheit(), 'f', 1, 64)
+}
+
+// celsius returns the temperature formatted in Celsius.
+func (t Temperature) celsius() string {
+	return strconv.FormatFloat(float64(t), 'f', 1, 64)
+}
+
+// Round returns the temperature rounded to the nearest 0.5 degree, the
+// resolution accepted by units. Halfway values round away from zero.
+func (t Temperature) Round() Temperature {
+	return Temperature(math.Round(float64(t)*2) / 2)
+}
+
+// Validate returns an error if the temperature is not a multiple of 0.5
+// degrees, and so would be rounded when sent to the unit.
+func (t Temperature) Validate() error {
+	if t.Round() != t {
+		return fmt.Errorf("temperature %s is not a multiple of 0.5", t.celsius())
+	}
+	return nil
+}
+
+// setUrlValues sets the temperature rounded to the nearest 0.5 degree.
 func (t *Temperature) setUrlValues(v url.Values) {
-	v.Set("stemp", t.String())
+	v.Set("stemp", t.Round().celsius())
 }
 
 func (t *Temperature) decode(v string) error {
 	val, err := strconv.ParseFloat(v, 64)
 	if err != nil {
-		return fmt.Errorf("error parsing s_temp=%s: %v", v, err)
+		return err
 	}
 	*t = Temperature(val)
 	return nil
 }
 
+// String returns the temperature formatted in DefaultUnit.
 func (t *Temperature) String() string {
-	return strconv.FormatFloat(float64(*t), 'f', 1, 64)
+	if DefaultUnit == Fahrenheit {
+		return t.StringF()
+	}
+	return t.celsius()
 }
 
 // Shum is the set humidity of the Daikin unit.
@@ -280,7 +376,7 @@ func (h *Humidity) decode(v string) error {
 	}
 	val, err := strconv.Atoi(v)
 	if err != nil {
-		return fmt.Errorf("error parsing s_hum=%s: %v", v, err)
+		return err
 	}
 	*h = Humidity(val)
 	return nil
@@ -293,8 +389,10 @@ func (n *Name) String() string {
 	return string(*n)
 }
 
+// setUrlValues sets the name query escaped, so reserved characters such
+// as & and = cannot split the form. Spaces are sent as %20 rather than +.
 func (n *Name) setUrlValues(v url.Values) {
-	v.Set("name", url.PathEscape(n.String()))
+	v.Set("name", strings.ReplaceAll(url.QueryEscape(n.String()), "+", "%20"))
 }
 
 func (n *Name) decode(s string) error {
@@ -310,22 +408,349 @@ func (n *Name) decode(s string) error {
 type Daikin struct {
 	// Address is the IP address of the unit.
 	Address string
+	// Token is the authentication token for adapters which require one.
+	Token string
+	// HTTPClient is the client used to talk to the unit. If nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+	// MaxAttempts is the maximum number of attempts for a request to the
+	// unit. Values below 2 disable retries.
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry. It doubles on each
+	// subsequent retry.
+	RetryDelay time.Duration
+	// Hostname, if set, is resolved to update Address before requests.
+	Hostname string
+	// ResolveInterval is how long Hostname's resolved address is used
+	// before resolving it again. If zero, it is 5 minutes.
+	ResolveInterval time.Duration
 	// Name is the human-readable name of the unit.
 	Name Name
+	// BasicInfo contains the basic device info.
+	BasicInfo *BasicInfo
+	// ModelInfo contains the model capabilities of the unit.
+	ModelInfo *ModelInfo
+	// WeekPowerInfo contains the energy consumption for the past week.
+	WeekPowerInfo *WeekPowerInfo
+	// YearPowerInfo contains the energy consumption for the year.
+	YearPowerInfo *YearPowerInfo
+	// TimerInfo contains the on/off timer settings.
+	TimerInfo *TimerInfo
+	// Program contains the weekly operating schedule.
+	Program *Program
+	// ScdlTimer contains the weekly schedule timer settings.
+	ScdlTimer *ScdlTimer
+	// NotifyInfo contains the maintenance notifications.
+	NotifyInfo *NotifyInfo
+	// PriceInfo contains the configured electricity price.
+	PriceInfo *PriceInfo
+	// TargetInfo contains the target values.
+	TargetInfo *TargetInfo
+	// RemoteMethodInfo contains how the unit is being controlled.
+	RemoteMethodInfo *RemoteMethodInfo
 	// ControlInfo contains the environment control info.
 	ControlInfo *ControlInfo
 	// SensorInfo contains the environment sensor info.
 	SensorInfo *SensorInfo
+
+	onFilterDirty func(*Daikin)
+	logger        *slog.Logger
+	hooks         hooks
+	vacation      vacation
+	// fetchMu serialises replacing fetched info and dispatching the
+	// hooks, so concurrent fetches report each change once, in order.
+	fetchMu sync.Mutex
+	// mu is the lock held by WithLock.
+	mu sync.Mutex
+	// rediscover is the network to find the unit on if it changes
+	// address, set by WithAutoRediscover.
+	rediscover *DaikinNetwork
+
+	// resolveMu guards Address against concurrent updates by resolve and
+	// refreshAddress.
+	resolveMu sync.Mutex
+	// resolved is when Hostname was last resolved.
+	resolved time.Time
+}
+
+// BasicInfo represents the basic identifying info of the unit.
+type BasicInfo struct {
+	// Type is the device type, eg "aircon".
+	Type string
+	// Region is the region code of the unit.
+	Region string
+	// DST is whether daylight savings time is enabled.
+	DST bool
+	// Version is the firmware version of the Wifi module.
+	Version string
+	// Revision is the firmware revision of the Wifi module.
+	Revision string
+	// Name is the human-readable name of the unit.
+	Name Name
+	// MAC is the MAC address of the Wifi module.
+	MAC string
+	// Datetime is the device date and time, if reported.
+	Datetime string
+}
+
+func (b *BasicInfo) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "type":
+			b.Type = v
+		case "reg":
+			b.Region = v
+		case "dst":
+			err = decodeBool(&b.DST, k, v)
+		case "ver":
+			b.Version = v
+		case "rev":
+			b.Revision = v
+		case "name":
+			err = b.Name.decode(v)
+		case "mac":
+			b.MAC = v
+		case "datetime":
+			b.Datetime = v
+		case "ret":
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+		}
+		if err != nil {
+			return &ParseError{Key: k, Value: v, Err: err}
+		}
+	}
+	return nil
+}
+
+func (b *BasicInfo) String() string {
+	return fmt.Sprintf("type: %s\nreg: %s\nver: %s\nrev: %s\nmac: %s\n", b.Type, b.Region, b.Version, b.Revision, b.MAC)
+}
+
+// ModelInfo represents the model info and capabilities of the unit. Many
+// fields are flags indicating whether the unit supports a given feature.
+type ModelInfo struct {
+	// Model is the model code of the unit.
+	Model string
+	// Type is the unit type code.
+	Type string
+	// PcType is the unit's PC board type.
+	PcType string
+	// InfType is the indoor unit interface type.
+	InfType string
+	// ElecFlagType is the electricity measurement type.
+	ElecFlagType string
+	// NSpd is the number of supported fan speeds.
+	NSpd int
+	// Humd is whether humidity is reported (humd).
+	Humd bool
+	// SHumd is whether a set humidity is supported (s_humd).
+	SHumd bool
+	// Acled is whether the unit has a controllable LED (acled).
+	Acled bool
+	// Land is the land flag (land).
+	Land bool
+	// Elec is whether power consumption is reported (elec).
+	Elec bool
+	// Temp is whether temperature can be set (temp).
+	Temp bool
+	// TempRng is the temperature range type (temp_rng).
+	TempRng int
+	// MDtct is whether the unit has a motion detector (m_dtct).
+	MDtct bool
+	// HumdFmt is the humidity format (humd_fmt).
+	HumdFmt string
+	// EnScdlTmr is whether the schedule timer is supported (en_scdltmr).
+	EnScdlTmr bool
+	// EnFRate is whether the fan speed can be set (en_frate).
+	EnFRate bool
+	// EnFDir is whether the louvre setting can be set (en_fdir).
+	EnFDir bool
+	// SFDir is the supported louvre settings (s_fdir), a bitmask of
+	// vertical (1), horizontal (2) and fixed positions (4).
+	SFDir int
+	// EnRTempA is whether comfort airflow is supported (en_rtemp_a).
+	EnRTempA bool
+	// EnSPMode is the supported special modes (en_spmode), a bitmask
+	// including powerful (1) and economy (2) modes.
+	EnSPMode int
+	// EnMomPow is whether instantaneous power is reported (en_mompow).
+	EnMomPow bool
+	// EnStreamer is whether Streamer air purification is supported
+	// (en_streamer).
+	EnStreamer bool
+}
+
+func (m *ModelInfo) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "model":
+			m.Model = v
+		case "type":
+			m.Type = v
+		case "pc_type":
+			m.PcType = v
+		case "inf_type":
+			m.InfType = v
+		case "elec_flag_type":
+			m.ElecFlagType = v
+		case "n_spd":
+			err = decodeInt(&m.NSpd, v)
+		case "humd":
+			err = decodeBool(&m.Humd, k, v)
+		case "s_humd":
+			err = decodeBool(&m.SHumd, k, v)
+		case "acled":
+			err = decodeBool(&m.Acled, k, v)
+		case "land":
+			err = decodeBool(&m.Land, k, v)
+		case "elec":
+			err = decodeBool(&m.Elec, k, v)
+		case "temp":
+			err = decodeBool(&m.Temp, k, v)
+		case "temp_rng":
+			err = decodeInt(&m.TempRng, v)
+		case "m_dtct":
+			err = decodeBool(&m.MDtct, k, v)
+		case "humd_fmt":
+			m.HumdFmt = v
+		case "en_scdltmr":
+			err = decodeBool(&m.EnScdlTmr, k, v)
+		case "en_frate":
+			err = decodeBool(&m.EnFRate, k, v)
+		case "en_fdir":
+			err = decodeBool(&m.EnFDir, k, v)
+		case "s_fdir":
+			err = decodeInt(&m.SFDir, v)
+		case "en_rtemp_a":
+			err = decodeBool(&m.EnRTempA, k, v)
+		case "en_spmode":
+			err = decodeInt(&m.EnSPMode, v)
+		case "en_mompow":
+			err = decodeBool(&m.EnMomPow, k, v)
+		case "en_streamer":
+			err = decodeBool(&m.EnStreamer, k, v)
+		case "ret":
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+		}
+		if err != nil {
+			return &ParseError{Key: k, Value: v, Err: err}
+		}
+	}
+	return nil
+}
+
+func (m *ModelInfo) String() string {
+	return fmt.Sprintf("model: %s\ntype: %s\nfan_speeds: %d\nhumd: %t\ntemp: %t\ntemp_rng: %d\nm_dtct: %t\ns_fdir: %d\nen_spmode: %d\n",
+		m.Model, m.Type, m.NSpd, m.Humd, m.Temp, m.TempRng, m.MDtct, m.SFDir, m.EnSPMode)
+}
+
+// temperatureRanges are the settable temperature ranges for each mode.
+var temperatureRanges = map[Mode][2]Temperature{
+	ModeCool:  {18, 32},
+	ModeHeat:  {10, 30},
+	ModeAuto:  {18, 30},
+	ModeAuto1: {18, 30},
+	ModeAuto7: {18, 30},
+}
+
+// TemperatureRange returns the settable temperature range for the given
+// mode. ok is false if the model cannot set the temperature in that mode.
+func (m *ModelInfo) TemperatureRange(mode Mode) (min, max Temperature, ok bool) {
+	r, ok := temperatureRanges[mode]
+	if !m.Temp || !ok {
+		return 0, 0, false
+	}
+	return r[0], r[1], true
+}
+
+// sFDirFixed is the SFDir bit set when fixed louvre positions are supported.
+const sFDirFixed = 4
+
+// checkControl returns an error if the settings of c are not supported by
+// the model.
+func (m *ModelInfo) checkControl(c *ControlInfo) error {
+	if err := m.checkFanDir(c); err != nil {
+		return err
+	}
+	if err := m.checkFeatures(c); err != nil {
+		return err
+	}
+	return m.checkTemperature(c)
+}
+
+// checkFanDir returns an error if the fan direction of c is not supported
+// by the model.
+func (m *ModelInfo) checkFanDir(c *ControlInfo) error {
+	if c.FanDir.fixed() && m.SFDir&sFDirFixed == 0 {
+		return &ErrUnsupported{Feature: "f_dir " + c.FanDir.String()}
+	}
+	return nil
+}
+
+// checkTemperature returns an error if the set temperature of c is not
+// supported by the model.
+func (m *ModelInfo) checkTemperature(c *ControlInfo) error {
+	min, max, ok := m.TemperatureRange(c.Mode)
+	if !ok {
+		return nil
+	}
+	if c.Temperature < min || c.Temperature > max {
+		return &ErrTemperatureOutOfRange{Requested: c.Temperature, Min: min, Max: max}
+	}
+	return nil
+}
+
+// decodeBool decodes a "0"/"1" flag value into b.
+func decodeBool(b *bool, key, s string) error {
+	switch s {
+	case "0":
+		*b = false
+	case "1":
+		*b = true
+	default:
+		return &ErrUnknownValue{Type: key, Value: s}
+	}
+	return nil
+}
+
+// decodeInt decodes an integer value into i. Placeholder values ("-" or
+// "--") decode as zero.
+func decodeInt(i *int, s string) error {
+	if s == "-" || s == "--" {
+		*i = 0
+		return nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
 }
 
 // SensorInfo represents current sensor values.
 type SensorInfo struct {
 	// HomeTemperature is the home (interior) temperature.
-	HomeTemperature Temperature
+	HomeTemperature Temperature `json:"home_temperature"`
 	// OutsideTemperature is the external temperature.
-	OutsideTemperature Temperature
+	OutsideTemperature Temperature `json:"outside_temperature"`
 	// Humidity is the current interior humidity.
-	Humidity Humidity
+	Humidity Humidity `json:"humidity"`
+	// CompressorFrequency is the compressor frequency in Hz, or nil if not
+	// reported. It is reported by BRP072A42 firmware 1_2_54 and later.
+	CompressorFrequency *int `json:"compressor_frequency,omitempty"`
+	// InstantPower is the instantaneous power consumption in W, or nil if
+	// not reported. It is reported by units with ModelInfo.EnMomPow set.
+	InstantPower *int `json:"instant_power,omitempty"`
+	// FilterDirty is whether the unit reports the filter needs cleaning or
+	// replacement.
+	FilterDirty bool `json:"filter_dirty"`
 }
 
 func (s *SensorInfo) populate(values map[string]string) error {
@@ -338,39 +763,75 @@ func (s *SensorInfo) populate(values map[string]string) error {
 			err = s.OutsideTemperature.decode(v)
 		case "hhum":
 			err = s.Humidity.decode(v)
+		case "f_mark":
+			err = decodeBool(&s.FilterDirty, k, v)
+		case "cmpfreq":
+			s.CompressorFrequency = new(int)
+			err = decodeInt(s.CompressorFrequency, v)
+		case "mompow":
+			// Reported in units of 100W.
+			var p int
+			if err = decodeInt(&p, v); err == nil {
+				p *= 100
+				s.InstantPower = &p
+			}
 		case "ret":
 			if v != returnOk {
-				err = fmt.Errorf("device returned error ret=%s", v)
+				return &DeviceError{Ret: v}
 			}
 		}
 		if err != nil {
-			return err
+			return &ParseError{Key: k, Value: v, Err: err}
 		}
 	}
 	return nil
 }
 
 func (s *SensorInfo) String() string {
-	return fmt.Sprintf("in_temp: %s\nin_humidity: %s\nout_temp: %s\n", s.HomeTemperature.String(), s.Humidity.String(), s.OutsideTemperature.String())
+	str := fmt.Sprintf("in_temp: %s\nin_humidity: %s\nout_temp: %s\n", s.HomeTemperature.String(), s.Humidity.String(), s.OutsideTemperature.String())
+	if s.CompressorFrequency != nil {
+		str += fmt.Sprintf("cmpfreq: %d\n", *s.CompressorFrequency)
+	}
+	if s.InstantPower != nil {
+		str += fmt.Sprintf("mompow: %dW\n", *s.InstantPower)
+	}
+	return str
 }
 
 // ControlInfo represents the control status of the unit.
 type ControlInfo struct {
 	// Power is the current power status of the unit.
-	Power Power
+	Power Power `json:"power"`
 	// Mode is the operating mode of the unit.
-	Mode Mode
+	Mode Mode `json:"mode"`
 	// Fan is the fan speed of the unit.
-	Fan Fan
+	Fan Fan `json:"fan"`
 	// FanDir is the fan louvre setting of the unit.
-	FanDir FanDir
+	FanDir FanDir `json:"fan_dir"`
 	// Temperature is the current set temperature of the unit.
-	Temperature Temperature
+	Temperature Temperature `json:"temperature"`
 	// Humidity is the set humidity of the unit.
-	Humidity Humidity
+	Humidity Humidity `json:"humidity"`
+	// Powerful is whether powerful (turbo) mode is on. Not all models
+	// support it.
+	Powerful bool `json:"powerful"`
+	// Eco is whether economy (energy saving) mode is on. Not all models
+	// support it.
+	Eco bool `json:"eco"`
+	// Streamer is whether Streamer air purification is on. Not all models
+	// support it.
+	Streamer bool `json:"streamer"`
+	// ComfortAirflow is whether comfort airflow is on, directing air away
+	// from occupants to avoid drafts. Not all models support it.
+	ComfortAirflow bool `json:"comfort_airflow"`
+	// IntelligentEye is whether the Intelligent Eye occupancy sensor is on,
+	// adjusting settings when the room is empty. Not all models have it.
+	IntelligentEye bool `json:"intelligent_eye"`
 }
 
-func (c *ControlInfo) urlValues() url.Values {
+// urlValues returns the settings to send to the unit. Optional features are
+// only included if supported by m, which may be nil if unknown.
+func (c *ControlInfo) urlValues(m *ModelInfo) url.Values {
 	qStr := url.Values{}
 	c.Power.setUrlValues(qStr)
 	c.Mode.setUrlValues(qStr)
@@ -378,6 +839,7 @@ func (c *ControlInfo) urlValues() url.Values {
 	c.FanDir.setUrlValues(qStr)
 	c.Temperature.setUrlValues(qStr)
 	c.Humidity.setUrlValues(qStr)
+	c.setFeatureUrlValues(m, qStr)
 	return qStr
 }
 
@@ -397,92 +859,330 @@ func (c *ControlInfo) populate(values map[string]string) error {
 			err = c.Fan.decode(v)
 		case "f_dir":
 			err = c.FanDir.decode(v)
+		case "adv":
+			c.decodeAdv(v)
+		case "eco":
+			err = decodeBool(&c.Eco, k, v)
+		case "streamer":
+			err = decodeBool(&c.Streamer, k, v)
+		case "en_rtemp_a":
+			err = decodeBool(&c.ComfortAirflow, k, v)
+		case "en_intelligent_eye":
+			err = decodeBool(&c.IntelligentEye, k, v)
 		case "ret":
 			if v != returnOk {
-				err = fmt.Errorf("device returned error ret=%s", v)
+				return &DeviceError{Ret: v}
 			}
 		}
 		if err != nil {
-			return err
+			return &ParseError{Key: k, Value: v, Err: err}
 		}
 	}
 	return nil
 }
 
 func (c *ControlInfo) String() string {
-	return fmt.Sprintf("pow: %s\nmode: %s\nstemp: %s\nshum: %s\nf_rate: %s\nf_dir: %s",
+	s := fmt.Sprintf("pow: %s\nmode: %s\nstemp: %s\nshum: %s\nf_rate: %s\nf_dir: %s",
 		c.Power.String(), c.Mode.String(), c.Temperature.String(), c.Humidity.String(), c.Fan.String(), c.FanDir.String())
+	if c.Powerful {
+		s += "\npowerful: true"
+	}
+	if c.Eco {
+		s += "\neco: true"
+	}
+	if c.Streamer {
+		s += "\nstreamer: true"
+	}
+	if c.ComfortAirflow {
+		s += "\ncomfort_airflow: true"
+	}
+	if c.IntelligentEye {
+		s += "\nintelligent_eye: true"
+	}
+	return s
 }
 
 func (d *Daikin) parseResponse(resp *http.Response) (map[string]string, error) {
 	defer resp.Body.Close()
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, &NetworkError{Address: d.address(), Err: err}
 	}
-	r := csv.NewReader(strings.NewReader(string(body)))
+	return parseValues(string(body))
+}
+
+// parseValues parses the comma separated key=value pairs of a response.
+func parseValues(body string) (map[string]string, error) {
+	r := csv.NewReader(strings.NewReader(body))
 	records, err := r.ReadAll()
 	if err != nil {
-		return nil, err
+		return nil, &ParseError{Err: err}
 	}
 	if len(records) != 1 {
-		return nil, fmt.Errorf("Have %d rows of records, want just one", len(records))
+		return nil, &ParseError{Err: fmt.Errorf("have %d rows of records, want just one", len(records))}
 	}
 
 	values := map[string]string{}
 	for _, rec := range records[0] {
 		parts := strings.SplitN(rec, "=", 2)
+		if len(parts) != 2 {
+			return nil, &ParseError{Err: fmt.Errorf("malformed field %q", rec)}
+		}
 		values[parts[0]] = parts[1]
 	}
 	return values, nil
+}
+
+// client returns the HTTP client to use for requests to the unit.
+func (d *Daikin) client() *http.Client {
+	if d.HTTPClient != nil {
+		return d.HTTPClient
+	}
+	return http.DefaultClient
+}
 
+// get fetches the given uri from the unit and returns the parsed response.
+func (d *Daikin) get(ctx context.Context, uri string) (map[string]string, error) {
+	return d.do(ctx, http.MethodGet, uri, "")
 }
 
-// Set configures the current setting to the unit.
-func (d *Daikin) SetControlInfo() error {
-	qStr := d.ControlInfo.urlValues()
-	resp, err := http.PostForm(fmt.Sprintf("http://%s%s", d.Address, uriSetControlInfo), qStr)
+// post sends the given values to the uri on the unit, and checks that the
+// unit accepted them.
+func (d *Daikin) post(ctx context.Context, uri string, qStr url.Values) error {
+	return d.postForm(ctx, uri, qStr.Encode())
+}
+
+// postForm sends the encoded form to the uri on the unit, and checks that
+// the unit accepted it.
+func (d *Daikin) postForm(ctx context.Context, uri, form string) error {
+	vals, err := d.do(ctx, http.MethodPost, uri, form)
 	if err != nil {
 		return err
 	}
-	vals, err := d.parseResponse(resp)
+	if v := vals["ret"]; v != returnOk {
+		return &DeviceError{Ret: v}
+	}
+	return nil
+}
+
+// populater is implemented by the types populated from a unit response.
+type populater interface {
+	populate(values map[string]string) error
+}
+
+// fetch gets the uri from the unit and populates v from the response.
+func (d *Daikin) fetch(ctx context.Context, uri string, v populater) error {
+	vals, err := d.get(ctx, uri)
 	if err != nil {
 		return err
 	}
-	if v := vals["ret"]; v != "OK" {
-		return fmt.Errorf("device returned error ret=%s", v)
+	return v.populate(vals)
+}
+
+// doOnce sends a single request to the unit and returns the parsed response.
+// For POST requests, form is sent as the encoded form body.
+func (d *Daikin) doOnce(ctx context.Context, method, uri, form string) (map[string]string, error) {
+	addr := d.resolve(ctx)
+	var body io.Reader
+	if method == http.MethodPost {
+		body = strings.NewReader(form)
 	}
-	return nil
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("http://%s%s", addr, uri), body)
+	if err != nil {
+		return nil, err
+	}
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if d.Token != "" {
+		req.Header.Set(tokenHeader, d.Token)
+	}
+	resp, err := d.client().Do(req)
+	if err != nil {
+		return nil, &NetworkError{Address: addr, Err: err}
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, &ErrUnsupported{Feature: uri}
+	default:
+		resp.Body.Close()
+		return nil, &NetworkError{Address: addr, Err: fmt.Errorf("%s: HTTP status %s", uri, resp.Status)}
+	}
+	return d.parseResponse(resp)
+}
+
+// SetControlInfo configures the current setting to the unit.
+func (d *Daikin) SetControlInfo() error {
+	return d.SetControlInfoContext(context.Background())
+}
+
+// SetControlInfoContext configures the current setting to the unit, using
+// the given context. If ModelInfo has been fetched, the settings are
+// checked against the model's capabilities and supported temperature range.
+// ModelInfo is fetched first if the settings enable an optional feature,
+// eg Powerful, as features are only sent once the model confirms support.
+func (d *Daikin) SetControlInfoContext(ctx context.Context) error {
+	if d.ModelInfo == nil && d.ControlInfo.featuresEnabled() {
+		if err := d.GetModelInfoContext(ctx); err != nil {
+			return err
+		}
+	}
+	if d.ModelInfo != nil {
+		if err := d.ModelInfo.checkControl(d.ControlInfo); err != nil {
+			return err
+		}
+	}
+	return d.post(ctx, uriSetControlInfo, d.ControlInfo.urlValues(d.ModelInfo))
 }
 
 // GetControlInfo gets the current control settings for the unit.
 func (d *Daikin) GetControlInfo() error {
-	resp, err := http.Get(fmt.Sprintf("http://%s%s", d.Address, uriGetControlInfo))
-	if err != nil {
+	return d.GetControlInfoContext(context.Background())
+}
+
+// GetControlInfoContext gets the current control settings for the unit,
+// using the given context.
+func (d *Daikin) GetControlInfoContext(ctx context.Context) error {
+	c := &ControlInfo{}
+	if err := d.fetch(ctx, uriGetControlInfo, c); err != nil {
 		return err
 	}
-	d.ControlInfo = &ControlInfo{}
-	vals, err := d.parseResponse(resp)
+	d.fetchMu.Lock()
+	defer d.fetchMu.Unlock()
+	prev := d.ControlInfo
+	d.ControlInfo = c
+	d.controlFetched(prev, c)
+	return nil
+}
+
+// GetBasicInfo gets the basic device info for the unit, and updates
+// the unit name.
+func (d *Daikin) GetBasicInfo() error {
+	return d.GetBasicInfoContext(context.Background())
+}
+
+// GetBasicInfoContext gets the basic device info for the unit, using the
+// given context.
+func (d *Daikin) GetBasicInfoContext(ctx context.Context) error {
+	b := &BasicInfo{}
+	if err := d.fetch(ctx, uriGetBasicInfo, b); err != nil {
+		return err
+	}
+	d.BasicInfo = b
+	d.Name = b.Name
+	return nil
+}
+
+// HealthCheck checks that the unit is reachable and responding, by fetching
+// its basic info once without retrying. The round trip latency is returned
+// even when the check fails.
+func (d *Daikin) HealthCheck(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	vals, err := d.doOnce(ctx, http.MethodGet, uriGetBasicInfo, "")
+	latency := time.Since(start)
 	if err != nil {
+		return latency, err
+	}
+	if v := vals["ret"]; v != returnOk {
+		return latency, &DeviceError{Ret: v}
+	}
+	return latency, nil
+}
+
+// SetName renames the unit, and updates Name on success. Only some firmware
+// versions support this, others return ErrUnsupported.
+func (d *Daikin) SetName(name string) error {
+	return d.SetNameContext(context.Background(), name)
+}
+
+// SetNameContext renames the unit, using the given context.
+func (d *Daikin) SetNameContext(ctx context.Context, name string) error {
+	n := Name(name)
+	qStr := url.Values{}
+	n.setUrlValues(qStr)
+	// The name is query escaped by setUrlValues, so is sent as is rather
+	// than encoded again.
+	if err := d.postForm(ctx, uriSetName, "name="+qStr.Get("name")); err != nil {
+		return err
+	}
+	d.Name = n
+	if d.BasicInfo != nil {
+		d.BasicInfo.Name = n
+	}
+	return nil
+}
+
+// GetModelInfo gets the model info and capabilities of the unit.
+func (d *Daikin) GetModelInfo() error {
+	return d.GetModelInfoContext(context.Background())
+}
+
+// GetModelInfoContext gets the model info and capabilities of the unit,
+// using the given context.
+func (d *Daikin) GetModelInfoContext(ctx context.Context) error {
+	m := &ModelInfo{}
+	if err := d.fetch(ctx, uriGetModelInfo, m); err != nil {
 		return err
 	}
-	return d.ControlInfo.populate(vals)
+	d.ModelInfo = m
+	return nil
 }
 
 // GetSensorInfo gets the current sensor values for the unit.
 func (d *Daikin) GetSensorInfo() error {
-	resp, err := http.Get(fmt.Sprintf("http://%s%s", d.Address, uriGetSensorInfo))
-	if err != nil {
+	return d.GetSensorInfoContext(context.Background())
+}
+
+// GetSensorInfoContext gets the current sensor values for the unit, using
+// the given context.
+func (d *Daikin) GetSensorInfoContext(ctx context.Context) error {
+	s := &SensorInfo{}
+	if err := d.fetch(ctx, uriGetSensorInfo, s); err != nil {
 		return err
 	}
-	d.SensorInfo = &SensorInfo{}
-	vals, err := d.parseResponse(resp)
-	if err != nil {
+	d.fetchMu.Lock()
+	defer d.fetchMu.Unlock()
+	prev := d.SensorInfo
+	d.SensorInfo = s
+	if s.FilterDirty && d.onFilterDirty != nil {
+		d.onFilterDirty(d)
+	}
+	d.sensorFetched(prev, s)
+	return nil
+}
+
+// GetAllInfo concurrently gets the control, sensor, basic and model info
+// for the unit. Either all of the info is updated, or on error none is.
+func (d *Daikin) GetAllInfo(ctx context.Context) error {
+	c, s, b, m := &ControlInfo{}, &SensorInfo{}, &BasicInfo{}, &ModelInfo{}
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error { return d.fetch(ctx, uriGetControlInfo, c) })
+	g.Go(func() error { return d.fetch(ctx, uriGetSensorInfo, s) })
+	g.Go(func() error { return d.fetch(ctx, uriGetBasicInfo, b) })
+	g.Go(func() error { return d.fetch(ctx, uriGetModelInfo, m) })
+	if err := g.Wait(); err != nil {
 		return err
 	}
-	return d.SensorInfo.populate(vals)
+	d.fetchMu.Lock()
+	defer d.fetchMu.Unlock()
+	prevControl, prevSensor := d.ControlInfo, d.SensorInfo
+	d.ControlInfo, d.SensorInfo, d.BasicInfo, d.ModelInfo = c, s, b, m
+	d.Name = b.Name
+	d.controlFetched(prevControl, c)
+	d.sensorFetched(prevSensor, s)
+	return nil
 }
 
 func (d *Daikin) String() string {
-	return fmt.Sprintf("name: %s\n%s\n%s\n", d.Name.String(), d.ControlInfo.String(), d.SensorInfo.String())
+	s := ""
+	if d.SensorInfo != nil && d.SensorInfo.FilterDirty {
+		s += "[FILTER REPLACEMENT DUE]\n"
+	}
+	s += fmt.Sprintf("name: %s\n", d.Name.String())
+	if d.BasicInfo != nil {
+		s += d.BasicInfo.String()
+	}
+	return s + fmt.Sprintf("%s\n%s\n", d.ControlInfo.String(), d.SensorInfo.String())
 }
@@ -0,0 +1,241 @@
+package daikin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// FuzzParseResponse checks that arbitrary responses are parsed without
+// panicking, and that failures are reported as a ParseError.
+func FuzzParseResponse(f *testing.F) {
+	// Responses captured from a BRP072A42 adapter.
+	f.Add("ret=OK,pow=1,mode=4,adv=,stemp=22.0,shum=0,dt1=25.0,dt2=M,dt3=22.0,dt4=22.0,dt5=22.0,dt7=25.0,dh1=AUTO,dh2=50,dh3=0,dh4=0,dh5=0,dh7=AUTO,dhh=50,b_mode=4,b_stemp=22.0,b_shum=0,alert=255,f_rate=A,f_dir=0,b_f_rate=A,b_f_dir=0,dfr1=5,dfr2=5,dfr3=5,dfr4=5,dfr5=5,dfr6=5,dfr7=5,dfrh=5,dfd1=0,dfd2=0,dfd3=0,dfd4=0,dfd5=0,dfd6=0,dfd7=0,dfdh=0")
+	f.Add("ret=OK,htemp=21.5,hhum=-,otemp=18.0,err=0,cmpfreq=0")
+	f.Add("ret=OK,type=aircon,reg=au,dst=1,ver=1_2_54,rev=203DE8C,pow=1,err=0,location=0,name=%4c%6f%75%6e%67%65,icon=0,method=home only,port=30050,id=,pw=,lpw_flag=0,adp_kind=3,pv=2,cpv=2,cpv_minor=00,led=1,en_setzone=1,mac=A408EAD3C3B6,adp_mode=run,en_hol=0,grp_name=,en_grp=0")
+	f.Add("ret=PARAM NG")
+	// Malformed responses.
+	f.Add("ret=OK,pow")
+	f.Add("")
+	f.Add("ret=OK,pow=1,")
+	f.Add("ret=OK\nret=OK")
+	f.Add(`ret="OK`)
+
+	f.Fuzz(func(t *testing.T, body string) {
+		d := &Daikin{Address: "192.0.2.1"}
+		resp := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+		vals, err := d.parseResponse(resp)
+		if err != nil {
+			var pErr *ParseError
+			if !errors.As(err, &pErr) {
+				t.Fatalf("parseResponse(%q) error = %v, want a ParseError", body, err)
+			}
+			return
+		}
+		// Populating from any parsed values must not panic.
+		(&ControlInfo{}).populate(vals)
+		(&SensorInfo{}).populate(vals)
+		(&BasicInfo{}).populate(vals)
+	})
+}
+
+func TestAddRemoveDevice(t *testing.T) {
+	n, err := NewNetwork()
+	if err != nil {
+		t.Fatalf("NewNetwork() = %v", err)
+	}
+	dev, err := n.AddDevice("192.0.2.1", WithToken("abc"), WithName("lounge"))
+	if err != nil {
+		t.Fatalf("AddDevice() = %v", err)
+	}
+	if dev.Token != "abc" || dev.Name != "lounge" {
+		t.Errorf("AddDevice() token=%q name=%q, want token=%q name=%q", dev.Token, dev.Name, "abc", "lounge")
+	}
+	// A second device at the same address is rejected, leaving the first.
+	if _, err := n.AddDevice("192.0.2.1", WithToken("def")); err == nil {
+		t.Error("AddDevice() of duplicate address succeeded, want error")
+	}
+	if got, ok := n.DeviceByAddress("192.0.2.1"); !ok || got != dev || got.Token != "abc" {
+		t.Errorf("DeviceByAddress() = %v, %t, want original device", got, ok)
+	}
+	if !n.RemoveDevice("192.0.2.1") {
+		t.Error("RemoveDevice() = false, want true")
+	}
+	if n.RemoveDevice("192.0.2.1") {
+		t.Error("RemoveDevice() of removed device = true, want false")
+	}
+	if _, err := n.AddDevice("192.0.2.1"); err != nil {
+		t.Errorf("AddDevice() after removal = %v", err)
+	}
+}
+
+func TestCIDRHosts(t *testing.T) {
+	tests := []struct {
+		cidr      string
+		wantErr   bool
+		wantLen   int
+		wantFirst string
+		wantLast  string
+	}{
+		{cidr: "10.0.0.0/15", wantErr: true},
+		{cidr: "10.0.0.0/16", wantLen: 65534, wantFirst: "10.0.0.1", wantLast: "10.0.255.254"},
+		{cidr: "192.168.1.17/24", wantLen: 254, wantFirst: "192.168.1.1", wantLast: "192.168.1.254"},
+		{cidr: "192.168.1.8/30", wantLen: 2, wantFirst: "192.168.1.9", wantLast: "192.168.1.10"},
+		// Point-to-point and single host subnets have no network or
+		// broadcast address to exclude.
+		{cidr: "192.168.1.8/31", wantLen: 2, wantFirst: "192.168.1.8", wantLast: "192.168.1.9"},
+		{cidr: "192.168.1.8/32", wantLen: 1, wantFirst: "192.168.1.8", wantLast: "192.168.1.8"},
+		{cidr: "2001:db8::/120", wantErr: true},
+		{cidr: "192.168.1.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			hosts, err := cidrHosts(tt.cidr)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("cidrHosts() returned %d hosts, want error", len(hosts))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cidrHosts() = %v", err)
+			}
+			if len(hosts) != tt.wantLen {
+				t.Fatalf("cidrHosts() returned %d hosts, want %d", len(hosts), tt.wantLen)
+			}
+			if got := hosts[0].String(); got != tt.wantFirst {
+				t.Errorf("first host = %s, want %s", got, tt.wantFirst)
+			}
+			if got := hosts[len(hosts)-1].String(); got != tt.wantLast {
+				t.Errorf("last host = %s, want %s", got, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := ControlInfo{
+		Power:       PowerOn,
+		Mode:        ModeCool,
+		Fan:         Fan3,
+		FanDir:      FanDirVertical,
+		Temperature: 22,
+		Humidity:    -1,
+	}
+	tests := []struct {
+		name string
+		set  func(c *ControlInfo)
+		// model, if set, is checked against.
+		model    *ModelInfo
+		wantErrs int
+	}{
+		{name: "valid", set: func(c *ControlInfo) {}},
+		{name: "humidity set", set: func(c *ControlInfo) { c.Humidity = 50 }},
+		{name: "humidity too high", set: func(c *ControlInfo) { c.Humidity = 101 }, wantErrs: 1},
+		{name: "humidity negative", set: func(c *ControlInfo) { c.Humidity = -2 }, wantErrs: 1},
+		{name: "temperature too low", set: func(c *ControlInfo) { c.Temperature = 17.5 }, wantErrs: 1},
+		{name: "temperature too high", set: func(c *ControlInfo) { c.Temperature = 32.5 }, wantErrs: 1},
+		{name: "temperature not half degree", set: func(c *ControlInfo) { c.Temperature = 22.3 }, wantErrs: 1},
+		{name: "heat range", set: func(c *ControlInfo) { c.Mode, c.Temperature = ModeHeat, 10 }},
+		{name: "dehumidify with fixed fan", set: func(c *ControlInfo) { c.Mode = ModeDehumidify }, wantErrs: 1},
+		{name: "dehumidify with auto fan", set: func(c *ControlInfo) { c.Mode, c.Fan = ModeDehumidify, FanAuto }},
+		{name: "unknown values", set: func(c *ControlInfo) { c.Power, c.Fan, c.FanDir = 5, "Z", 99 }, wantErrs: 3},
+		// All violations are reported, not just the first.
+		{name: "multiple", set: func(c *ControlInfo) { c.Temperature, c.Humidity = 40, 200 }, wantErrs: 2},
+		{name: "feature without model", set: func(c *ControlInfo) { c.Powerful = true }},
+		{name: "unsupported feature", set: func(c *ControlInfo) { c.Powerful, c.Streamer = true, true }, model: &ModelInfo{}, wantErrs: 2},
+		{name: "supported feature", set: func(c *ControlInfo) { c.Powerful = true }, model: &ModelInfo{EnSPMode: spModePowerful}},
+		{name: "unsupported fixed louvre", set: func(c *ControlInfo) { c.FanDir = FanDirFixedMid }, model: &ModelInfo{}, wantErrs: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.set(&c)
+			if errs := c.Validate(tt.model); len(errs) != tt.wantErrs {
+				t.Errorf("Validate() = %v, want %d errors", errs, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	devices := []*Daikin{
+		{Address: "192.0.2.1", Name: "lounge", ControlInfo: &ControlInfo{
+			Power: PowerOn, Mode: ModeHeat, Temperature: 21.5, Humidity: -1, Fan: FanSilent, FanDir: FanDirBoth,
+		}},
+		{Address: "192.0.2.2", Name: "bed, upstairs"},
+	}
+	var buf strings.Builder
+	if err := ExportCSV(&buf, devices); err != nil {
+		t.Fatalf("ExportCSV() = %v", err)
+	}
+	got, err := ImportCSV(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("ImportCSV() = %v\n%s", err, buf.String())
+	}
+	if len(got) != len(devices) {
+		t.Fatalf("ImportCSV() returned %d devices, want %d", len(got), len(devices))
+	}
+	for i, d := range devices {
+		g := got[i]
+		if g.Address != d.Address || g.Name != d.Name {
+			t.Errorf("device %d = %s %q, want %s %q", i, g.Address, g.Name, d.Address, d.Name)
+		}
+		switch {
+		case d.ControlInfo == nil && g.ControlInfo != nil:
+			t.Errorf("device %d ControlInfo = %+v, want nil", i, *g.ControlInfo)
+		case d.ControlInfo != nil && (g.ControlInfo == nil || *g.ControlInfo != *d.ControlInfo):
+			t.Errorf("device %d ControlInfo = %+v, want %+v", i, g.ControlInfo, *d.ControlInfo)
+		}
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	const header = "address,name,power,mode,temperature,humidity,fan,fandir\n"
+	tests := []struct {
+		name    string
+		in      string
+		want    ControlInfo
+		wantErr bool
+	}{
+		{
+			name: "protocol values",
+			in:   header + "192.0.2.1,lounge,1,3,24.0,50,B,1\n",
+			want: ControlInfo{Power: PowerOn, Mode: ModeCool, Temperature: 24, Humidity: 50, Fan: FanSilent, FanDir: FanDirVertical},
+		},
+		{
+			name: "names",
+			in:   header + "192.0.2.1,lounge,Off,Cool,24.0,-,Auto,Stopped\n",
+			want: ControlInfo{Power: PowerOff, Mode: ModeCool, Temperature: 24, Humidity: -1, Fan: FanAuto, FanDir: FanDirStopped},
+		},
+		{name: "empty", in: "", wantErr: true},
+		{name: "wrong header", in: "address,name,power\n", wantErr: true},
+		{name: "renamed column", in: "address,name,power,mode,temp,humidity,fan,fandir\n", wantErr: true},
+		{name: "short row", in: header + "192.0.2.1,lounge\n", wantErr: true},
+		{name: "bad mode", in: header + "192.0.2.1,lounge,On,Sauna,24.0,-,Auto,Stopped\n", wantErr: true},
+		{name: "bad temperature", in: header + "192.0.2.1,lounge,On,Cool,warm,-,Auto,Stopped\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devices, err := ImportCSV(strings.NewReader(tt.in))
+			if tt.wantErr {
+				var pe *ParseError
+				if !errors.As(err, &pe) {
+					t.Errorf("ImportCSV() = %v, want ParseError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportCSV() = %v", err)
+			}
+			if len(devices) != 1 || devices[0].ControlInfo == nil {
+				t.Fatalf("ImportCSV() = %v, want one device with ControlInfo", devices)
+			}
+			if got := *devices[0].ControlInfo; got != tt.want {
+				t.Errorf("ImportCSV() ControlInfo = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
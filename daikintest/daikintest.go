@@ -0,0 +1,251 @@
+// Package daikintest provides a mock Daikin unit for testing code which
+// uses the daikin package without a physical device.
+package daikintest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+// Default responses for endpoints without modelled state, captured from a
+// BRP072A42 module.
+var defaultResponses = map[string]string{
+	"/common/basic_info":        "ret=OK,type=aircon,reg=au,dst=1,ver=1_2_54,rev=203DE8C,pow=1,err=0,location=0,name=%4d%6f%63%6b,icon=0,method=home only,port=30050,id=,pw=,lpw_flag=0,adp_kind=3,pv=2,cpv=2,cpv_minor=00,led=1,en_setzone=1,mac=A408EAD3C3B6,adp_mode=run,en_hol=0,grp_name=,en_grp=0",
+	"/common/get_remote_method": "ret=OK,method=home only,notice_ip_int=3600,notice_sync_int=60",
+	"/aircon/get_model_info":    "ret=OK,model=0AB9,type=N,pv=2,cpv=2,cpv_minor=00,mid=NA,humd=0,s_humd=0,acled=0,land=0,elec=0,temp=1,temp_rng=0,m_dtct=1,ac_dst=--,disp_dry=0,dmnd=0,en_scdltmr=1,en_frate=1,en_fdir=1,s_fdir=3,en_rtemp_a=0,en_spmode=0,en_ipw_sep=0,en_mompow=0",
+	"/aircon/get_timer":         "ret=OK,fthr_on=0,fthr_off=0,dtim_on=0,dtim_off=0",
+	"/aircon/get_price":         "ret=OK,price_int=27,price_dec=0",
+	"/aircon/get_target":        "ret=OK,target=0",
+	"/aircon/get_week_power":    "ret=OK,today_runtime=601,datas=0/0/0/0/0/0/1000",
+	"/aircon/get_year_power":    "ret=OK,previous_year=0/0/0/0/0/0/0/0/0/0/0/0,this_year=0/0/0/0/0/0/0/0/0/0/0/0",
+	"/aircon/get_program":       "ret=OK",
+	"/aircon/get_scdltimer":     "ret=OK,format=v1,f_detail=total#18;_en#1;_pow#1;_mode#1;_temp#4;_time#4;_vol#1;_dir#1;_humi#3;_spmd#2,scdl_num=3,scdl_per_day=6,en_scdltimer=0,active_no=1,scdl1_name=,scdl2_name=,scdl3_name=",
+	"/aircon/get_notify":        "ret=OK,auto_off_flg=0,auto_off_tm=- -",
+	"/aircon/set_timer":         "ret=OK",
+	"/aircon/set_program":       "ret=OK",
+	"/aircon/set_scdltimer":     "ret=OK",
+}
+
+// MockDevice is a mock Daikin unit served over HTTP.
+type MockDevice struct {
+	// Server is the underlying test server.
+	Server *httptest.Server
+
+	mu        sync.Mutex
+	control   daikin.ControlInfo
+	sensor    daikin.SensorInfo
+	responses map[string]string
+	token     string
+	header    http.Header
+}
+
+// NewMockDevice starts a mock unit, which is shut down when the test
+// completes. The unit starts powered off in auto mode at 22 degrees.
+func NewMockDevice(t testing.TB) *MockDevice {
+	m := &MockDevice{
+		control: daikin.ControlInfo{
+			Power:       daikin.PowerOff,
+			Mode:        daikin.ModeAuto,
+			Fan:         daikin.FanAuto,
+			FanDir:      daikin.FanDirStopped,
+			Temperature: 22,
+		},
+		sensor: daikin.SensorInfo{
+			HomeTemperature:    21.5,
+			OutsideTemperature: 18,
+			Humidity:           -1,
+		},
+		responses: map[string]string{},
+	}
+	for k, v := range defaultResponses {
+		m.responses[k] = v
+	}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	t.Cleanup(m.Server.Close)
+	return m
+}
+
+// Address returns the address of the mock unit, for use as Daikin.Address.
+func (m *MockDevice) Address() string {
+	return strings.TrimPrefix(m.Server.URL, "http://")
+}
+
+// Device returns a new Daikin for the mock unit.
+func (m *MockDevice) Device() *daikin.Daikin {
+	return &daikin.Daikin{Address: m.Address()}
+}
+
+// State returns the current control state of the unit.
+func (m *MockDevice) State() daikin.ControlInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.control
+}
+
+// SetState sets the current control state of the unit.
+func (m *MockDevice) SetState(c daikin.ControlInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.control = c
+}
+
+// SensorState returns the current sensor values of the unit.
+func (m *MockDevice) SensorState() daikin.SensorInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sensor
+}
+
+// SetSensorState sets the current sensor values of the unit.
+func (m *MockDevice) SetSensorState(s daikin.SensorInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sensor = s
+}
+
+// SetResponse sets the raw response body returned for the given uri, eg
+// "/aircon/get_model_info". An empty body makes the uri return 404.
+func (m *MockDevice) SetResponse(uri, body string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses[uri] = body
+}
+
+// SetToken makes the unit require the given authentication token, as
+// BRP072C42 and newer adapters do. Requests without it are rejected with
+// 403. An empty token accepts all requests.
+func (m *MockDevice) SetToken(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.token = token
+}
+
+// LastHeader returns the headers of the last request to the unit, or nil
+// if none has been made.
+func (m *MockDevice) LastHeader() http.Header {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.header
+}
+
+func (m *MockDevice) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.header = r.Header.Clone()
+	if m.token != "" && r.Header.Get("X-Daikin-uuid") != m.token {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	switch r.URL.Path {
+	case "/aircon/get_control_info":
+		fmt.Fprint(w, encodeControl(&m.control))
+	case "/aircon/get_sensor_info":
+		fmt.Fprintf(w, "ret=OK,htemp=%s,hhum=%s,otemp=%s,err=0,cmpfreq=0",
+			formatTemp(m.sensor.HomeTemperature), formatHumidity(m.sensor.Humidity), formatTemp(m.sensor.OutsideTemperature))
+		if m.sensor.FilterDirty {
+			fmt.Fprint(w, ",f_mark=1")
+		}
+	case "/aircon/set_control_info":
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c, err := decodeControl(r.Form)
+		if err != nil {
+			fmt.Fprint(w, "ret=PARAM NG")
+			return
+		}
+		m.control = c
+		fmt.Fprint(w, "ret=OK")
+	default:
+		body, ok := m.responses[r.URL.Path]
+		if !ok || body == "" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}
+}
+
+func formatTemp(t daikin.Temperature) string {
+	return strconv.FormatFloat(float64(t), 'f', 1, 64)
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func formatHumidity(h daikin.Humidity) string {
+	if h < 0 {
+		return "-"
+	}
+	return strconv.Itoa(int(h))
+}
+
+func encodeControl(c *daikin.ControlInfo) string {
+	adv := ""
+	if c.Powerful {
+		adv = "2"
+	}
+	return fmt.Sprintf("ret=OK,pow=%d,mode=%d,adv=%s,stemp=%s,shum=%s,f_rate=%s,f_dir=%d,eco=%s,en_intelligent_eye=%s,en_rtemp_a=%s,streamer=%s",
+		c.Power, c.Mode, adv, formatTemp(c.Temperature), formatHumidity(c.Humidity), string(c.Fan), c.FanDir, formatBool(c.Eco), formatBool(c.IntelligentEye), formatBool(c.ComfortAirflow), formatBool(c.Streamer))
+}
+
+// validFans are the protocol values accepted for f_rate.
+var validFans = map[string]bool{"A": true, "B": true, "3": true, "4": true, "5": true, "6": true, "7": true}
+
+// decodeControl decodes the parameters sent to set_control_info, rejecting
+// invalid values as a real unit would.
+func decodeControl(v url.Values) (daikin.ControlInfo, error) {
+	var c daikin.ControlInfo
+	pow, err := strconv.Atoi(v.Get("pow"))
+	if err != nil || pow < 0 || pow > 1 {
+		return c, fmt.Errorf("bad pow: %q", v.Get("pow"))
+	}
+	c.Power = daikin.Power(pow)
+	mode, err := strconv.Atoi(v.Get("mode"))
+	if err != nil || mode == 5 || mode < 0 || mode > 7 {
+		return c, fmt.Errorf("bad mode: %q", v.Get("mode"))
+	}
+	c.Mode = daikin.Mode(mode)
+	temp, err := strconv.ParseFloat(v.Get("stemp"), 64)
+	if err != nil {
+		return c, fmt.Errorf("bad stemp: %q", v.Get("stemp"))
+	}
+	c.Temperature = daikin.Temperature(temp)
+	if h := v.Get("shum"); h != "" && h != "-" {
+		hum, err := strconv.Atoi(h)
+		if err != nil {
+			return c, fmt.Errorf("bad shum: %q", h)
+		}
+		c.Humidity = daikin.Humidity(hum)
+	} else {
+		c.Humidity = -1
+	}
+	if !validFans[v.Get("f_rate")] {
+		return c, fmt.Errorf("bad f_rate: %q", v.Get("f_rate"))
+	}
+	c.Fan = daikin.Fan(v.Get("f_rate"))
+	fd, err := daikin.ParseFanDir(v.Get("f_dir"))
+	if err != nil {
+		return c, err
+	}
+	c.FanDir = fd
+	c.Powerful = v.Get("adv") == "2"
+	c.Eco = v.Get("eco") == "1"
+	c.IntelligentEye = v.Get("en_intelligent_eye") == "1"
+	c.ComfortAirflow = v.Get("en_rtemp_a") == "1"
+	c.Streamer = v.Get("streamer") == "1"
+	return c, nil
+}
@@ -0,0 +1,84 @@
+package daikintest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+func TestControlRoundTrip(t *testing.T) {
+	m := NewMockDevice(t)
+	// A model supporting powerful mode and the streamer.
+	m.SetResponse("/aircon/get_model_info", strings.Replace(defaultResponses["/aircon/get_model_info"], "en_spmode=0", "en_spmode=1,en_streamer=1", 1))
+	d := m.Device()
+	want := daikin.ControlInfo{
+		Power:       daikin.PowerOn,
+		Mode:        daikin.ModeCool,
+		Fan:         daikin.Fan4,
+		FanDir:      daikin.FanDirBoth,
+		Temperature: 24.5,
+		Humidity:    -1,
+		Powerful:    true,
+		Streamer:    true,
+	}
+	c := want
+	d.ControlInfo = &c
+	if err := d.SetControlInfo(); err != nil {
+		t.Fatalf("SetControlInfo() = %v", err)
+	}
+	if got := m.State(); got != want {
+		t.Errorf("State() = %+v, want %+v", got, want)
+	}
+
+	if err := d.GetControlInfo(); err != nil {
+		t.Fatalf("GetControlInfo() = %v", err)
+	}
+	if got := *d.ControlInfo; got != want {
+		t.Errorf("GetControlInfo() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSensorRoundTrip(t *testing.T) {
+	m := NewMockDevice(t)
+	d := m.Device()
+	want := daikin.SensorInfo{
+		HomeTemperature:    23.5,
+		OutsideTemperature: -2,
+		Humidity:           55,
+		FilterDirty:        true,
+	}
+	m.SetSensorState(want)
+	if err := d.GetSensorInfo(); err != nil {
+		t.Fatalf("GetSensorInfo() = %v", err)
+	}
+	got := *d.SensorInfo
+	got.CompressorFrequency = nil
+	if got != want {
+		t.Errorf("GetSensorInfo() = %+v, want %+v", got, want)
+	}
+	if got := m.SensorState(); got != want {
+		t.Errorf("SensorState() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSetControlParamNG(t *testing.T) {
+	m := NewMockDevice(t)
+	d := m.Device()
+	before := m.State()
+	d.ControlInfo = &daikin.ControlInfo{
+		Power:       daikin.PowerOn,
+		Mode:        daikin.ModeHeat,
+		Fan:         daikin.Fan("Z"),
+		Temperature: 22,
+	}
+	err := d.SetControlInfo()
+	var dErr *daikin.DeviceError
+	if !errors.As(err, &dErr) || dErr.Ret != "PARAM NG" {
+		t.Fatalf("SetControlInfo() with invalid fan = %v, want DeviceError PARAM NG", err)
+	}
+	if got := m.State(); got != before {
+		t.Errorf("State() after rejected set = %+v, want unchanged %+v", got, before)
+	}
+}
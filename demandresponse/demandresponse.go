@@ -0,0 +1,107 @@
+// Package demandresponse reduces the load of Daikin units during utility
+// demand response events, by temporarily shifting the set temperature.
+package demandresponse
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+const (
+	// reductionPerDegree is the approximate fraction of load saved by each
+	// degree of set temperature shift.
+	reductionPerDegree = 0.1
+	// maxShift is the largest set temperature shift, in degrees.
+	maxShift = 4
+)
+
+// DemandEvent is a period in which load should be reduced.
+type DemandEvent struct {
+	// Start is when the event starts.
+	Start time.Time
+	// End is when the event ends.
+	End time.Time
+	// TargetReduction is the fraction of load to shed, eg 0.2 for 20%.
+	TargetReduction float64
+}
+
+// Shift returns the set temperature shift for the event, in degrees. It
+// assumes each degree sheds 10% of load, and is capped at 4 degrees.
+func (e DemandEvent) Shift() daikin.Temperature {
+	shift := math.Min(e.TargetReduction/reductionPerDegree, maxShift)
+	return daikin.Temperature(math.Max(shift, 0)).Round()
+}
+
+// Responder shifts the set temperature of a unit during demand events.
+type Responder struct {
+	// Device is the unit to control.
+	Device *daikin.Daikin
+	// BaselineTemp is the set temperature restored when an event ends.
+	BaselineTemp daikin.Temperature
+}
+
+// Respond waits for the event to start, then lowers the set temperature
+// if the unit is heating, or raises it if cooling, by the event's Shift.
+// Units in other modes are left unchanged. When the event ends, or the
+// context is done, the set temperature is restored to BaselineTemp. It
+// blocks until then, so should usually be run in a goroutine.
+func (r *Responder) Respond(ctx context.Context, event DemandEvent) error {
+	if !event.End.After(event.Start) {
+		return fmt.Errorf("event end %s is not after start %s", event.End.Format(time.RFC3339), event.Start.Format(time.RFC3339))
+	}
+	if err := wait(ctx, event.Start); err != nil {
+		return err
+	}
+	if !time.Now().Before(event.End) {
+		return nil
+	}
+	d := r.Device
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return err
+	}
+	shift := event.Shift()
+	switch d.ControlInfo.Mode {
+	case daikin.ModeHeat:
+		shift = -shift
+	case daikin.ModeCool:
+	default:
+		return nil
+	}
+	d.ControlInfo.Temperature = r.BaselineTemp + shift
+	if err := d.SetControlInfoContext(ctx); err != nil {
+		return err
+	}
+
+	err := wait(ctx, event.End)
+	// Restore even if the context is done.
+	if rerr := r.restore(context.WithoutCancel(ctx)); rerr != nil {
+		return rerr
+	}
+	return err
+}
+
+// restore sets the unit's set temperature back to the baseline.
+func (r *Responder) restore(ctx context.Context) error {
+	d := r.Device
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return err
+	}
+	d.ControlInfo.Temperature = r.BaselineTemp
+	return d.SetControlInfoContext(ctx)
+}
+
+// wait waits until t, or the context is done.
+func wait(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
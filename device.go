@@ -0,0 +1,46 @@
+package daikin
+
+import "log/slog"
+
+// DeviceOption configures a Daikin.
+type DeviceOption func(*Daikin)
+
+// Apply configures the unit with the given options.
+func (d *Daikin) Apply(opts ...DeviceOption) {
+	for _, o := range opts {
+		o(d)
+	}
+}
+
+// WithToken configures the authentication token, for adapters which
+// require one.
+func WithToken(token string) DeviceOption {
+	return func(d *Daikin) {
+		d.Token = token
+	}
+}
+
+// WithName configures the human-readable name of the unit. It is replaced
+// by the unit's own name when its basic info is fetched.
+func WithName(name string) DeviceOption {
+	return func(d *Daikin) {
+		d.Name = Name(name)
+	}
+}
+
+// WithLogger configures the logger for warnings from the unit. By default
+// the network's logger is used, or slog.Default() if the unit is not on a
+// network.
+func WithLogger(l *slog.Logger) DeviceOption {
+	return func(d *Daikin) {
+		d.logger = l
+	}
+}
+
+// log returns the unit's logger.
+func (d *Daikin) log() *slog.Logger {
+	if d.logger != nil {
+		return d.logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,132 @@
+// Package dogstatsd forwards the state of Daikin units to a Datadog agent
+// as DogStatsD gauges.
+//
+// The gauges are daikin.up, daikin.indoor_temperature,
+// daikin.outdoor_temperature, daikin.indoor_humidity,
+// daikin.set_temperature, daikin.power_state, daikin.mode and
+// daikin.fan_speed, matching the metrics package. Each is tagged with
+// device_name and device_address. A unit which can't be queried reports
+// only daikin.up as 0.
+package dogstatsd
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/DataDog/datadog-go/v5/statsd"
+	"github.com/buxtronix/go-daikin"
+)
+
+// Forwarder forwards the state of a set of units.
+type Forwarder struct {
+	client  statsd.ClientInterface
+	devices []*daikin.Daikin
+	logger  *slog.Logger
+}
+
+// LoggerOption configures the logger for errors from Run. By default
+// slog.Default() is used.
+func LoggerOption(l *slog.Logger) func(*Forwarder) {
+	return func(f *Forwarder) {
+		f.logger = l
+	}
+}
+
+// NewForwarder returns a Forwarder for the devices, sending to the
+// DogStatsD agent at addr, eg "127.0.0.1:8125".
+func NewForwarder(addr string, devices []*daikin.Daikin, opts ...func(*Forwarder)) (*Forwarder, error) {
+	client, err := statsd.New(addr)
+	if err != nil {
+		return nil, err
+	}
+	f := &Forwarder{
+		client:  client,
+		devices: devices,
+		logger:  slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f, nil
+}
+
+// Close flushes any buffered metrics and closes the connection to the
+// agent.
+func (f *Forwarder) Close() error {
+	return f.client.Close()
+}
+
+// Forward fetches the state of all units and sends their gauges. It
+// returns the first error sending to the agent, or ctx's error if it is
+// done; units which can't be queried are reported by daikin.up.
+func (f *Forwarder) Forward(ctx context.Context) error {
+	for _, d := range f.devices {
+		if err := f.forwardDevice(ctx, d); err != nil {
+			return err
+		}
+	}
+	return f.client.Flush()
+}
+
+func (f *Forwarder) forwardDevice(ctx context.Context, d *daikin.Daikin) error {
+	tags := []string{"device_name:" + d.Name.String(), "device_address:" + d.Address}
+	gauge := func(name string, v float64) error {
+		return f.client.Gauge("daikin."+name, v, tags, 1)
+	}
+	down := func() error {
+		// A unit isn't down because forwarding was stopped.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return gauge("up", 0)
+	}
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return down()
+	}
+	if err := d.GetSensorInfoContext(ctx); err != nil {
+		return down()
+	}
+	s, ci := d.SensorInfo, d.ControlInfo
+	gauges := map[string]float64{
+		"up":                  1,
+		"indoor_temperature":  float64(s.HomeTemperature),
+		"outdoor_temperature": float64(s.OutsideTemperature),
+		"set_temperature":     float64(ci.Temperature),
+		"power_state":         float64(ci.Power),
+		"mode":                float64(ci.Mode),
+	}
+	if s.Humidity >= 0 {
+		gauges["indoor_humidity"] = float64(s.Humidity)
+	}
+	if v, ok := ci.Fan.Level(); ok {
+		gauges["fan_speed"] = float64(v)
+	}
+	for name, v := range gauges {
+		if err := gauge(name, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run forwards immediately and then every interval, until ctx is done.
+// Errors are logged, and forwarding continues. If interval is not
+// positive, one minute is used.
+func (f *Forwarder) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := f.Forward(ctx); err != nil && ctx.Err() == nil {
+			f.logger.Error("forwarding to DogStatsD failed", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package daikin
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrTemperatureOutOfRange is returned when a set temperature is outside
+// the range supported by the unit.
+type ErrTemperatureOutOfRange struct {
+	// Requested is the requested temperature.
+	Requested Temperature
+	// Min and Max are the allowed temperature range.
+	Min, Max Temperature
+}
+
+func (e *ErrTemperatureOutOfRange) Error() string {
+	return fmt.Sprintf("temperature %s out of range [%s, %s]", e.Requested.String(), e.Min.String(), e.Max.String())
+}
+
+// ErrUnknownValue is returned when parsing an unrecognised value.
+type ErrUnknownValue struct {
+	// Type is the type of value being parsed, eg "mode".
+	Type string
+	// Value is the unrecognised value.
+	Value string
+}
+
+func (e *ErrUnknownValue) Error() string {
+	return fmt.Sprintf("unknown %s value: %s", e.Type, e.Value)
+}
+
+// NetworkError is returned when a unit could not be reached, or the request
+// to it failed.
+type NetworkError struct {
+	// Address is the address of the unit.
+	Address string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Address, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// ErrParamNG is wrapped by a DeviceError when the unit rejected the
+// request parameters.
+var ErrParamNG = errors.New("parameters rejected by device")
+
+// ErrNoSensorInfo is returned when averaging sensor values, if no unit has
+// sensor info.
+var ErrNoSensorInfo = errors.New("no sensor info")
+
+// DeviceError is returned when a unit responds with an error.
+type DeviceError struct {
+	// Ret is the ret= value returned by the unit, eg "PARAM NG".
+	Ret string
+}
+
+func (e *DeviceError) Error() string {
+	return fmt.Sprintf("device returned error ret=%s", e.Ret)
+}
+
+// Unwrap returns ErrParamNG if the unit rejected the parameters.
+func (e *DeviceError) Unwrap() error {
+	if e.Ret == returnBad {
+		return ErrParamNG
+	}
+	return nil
+}
+
+// ParseError is returned when a response from a unit is malformed.
+type ParseError struct {
+	// Key and Value are the field that failed to parse, if known.
+	Key, Value string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("error parsing response: %v", e.Err)
+	}
+	return fmt.Sprintf("error parsing %s=%s: %v", e.Key, e.Value, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnsupported is returned when the unit does not support a feature.
+type ErrUnsupported struct {
+	// Feature is the unsupported feature or endpoint.
+	Feature string
+}
+
+func (e *ErrUnsupported) Error() string {
+	return fmt.Sprintf("not supported by device: %s", e.Feature)
+}
+
+// GroupError is returned when an operation on multiple units failed for
+// some of them. It maps the address of each failed unit to its error.
+type GroupError map[string]error
+
+func (e GroupError) Error() string {
+	addrs := make([]string, 0, len(e))
+	for a := range e {
+		addrs = append(addrs, a)
+	}
+	sort.Strings(addrs)
+	msgs := make([]string, len(addrs))
+	for i, a := range addrs {
+		msgs[i] = e[a].Error()
+		// NetworkError already includes the address.
+		if !strings.HasPrefix(msgs[i], a) {
+			msgs[i] = a + ": " + msgs[i]
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of the failed units.
+func (e GroupError) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,6 @@
+package daikin
+
+// AddDiscovered exposes addDiscovered to the external tests.
+func (d *DaikinNetwork) AddDiscovered(ip, reply string) {
+	d.addDiscovered(ip, reply)
+}
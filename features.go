@@ -0,0 +1,112 @@
+package daikin
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Special mode bits of ModelInfo.EnSPMode.
+const (
+	spModePowerful = 1
+	spModeEcono    = 2
+)
+
+// advPowerful is the adv value reported when powerful mode is on.
+const advPowerful = "2"
+
+// controlFeature is an optional ControlInfo setting, only supported by
+// some models. Features are only sent to the unit if ModelInfo has been
+// fetched and confirms support.
+type controlFeature struct {
+	// name is the feature name reported by ErrUnsupported.
+	name string
+	// enabled returns whether the feature is enabled in c.
+	enabled func(c *ControlInfo) bool
+	// supported returns whether the model supports the feature.
+	supported func(m *ModelInfo) bool
+	// setUrlValues puts the feature's setting into v.
+	setUrlValues func(c *ControlInfo, v url.Values)
+}
+
+var controlFeatures = []controlFeature{
+	{
+		name:      "powerful",
+		enabled:   func(c *ControlInfo) bool { return c.Powerful },
+		supported: func(m *ModelInfo) bool { return m.EnSPMode&spModePowerful != 0 },
+		setUrlValues: func(c *ControlInfo, v url.Values) {
+			adv := ""
+			if c.Powerful {
+				adv = advPowerful
+			}
+			v.Set("adv", adv)
+		},
+	},
+	{
+		name:         "eco",
+		enabled:      func(c *ControlInfo) bool { return c.Eco },
+		supported:    func(m *ModelInfo) bool { return m.EnSPMode&spModeEcono != 0 },
+		setUrlValues: func(c *ControlInfo, v url.Values) { v.Set("eco", encodeBool(c.Eco)) },
+	},
+	{
+		name:         "streamer",
+		enabled:      func(c *ControlInfo) bool { return c.Streamer },
+		supported:    func(m *ModelInfo) bool { return m.EnStreamer },
+		setUrlValues: func(c *ControlInfo, v url.Values) { v.Set("streamer", encodeBool(c.Streamer)) },
+	},
+	{
+		name:         "comfort_airflow",
+		enabled:      func(c *ControlInfo) bool { return c.ComfortAirflow },
+		supported:    func(m *ModelInfo) bool { return m.EnRTempA },
+		setUrlValues: func(c *ControlInfo, v url.Values) { v.Set("en_rtemp_a", encodeBool(c.ComfortAirflow)) },
+	},
+	{
+		name:         "intelligent_eye",
+		enabled:      func(c *ControlInfo) bool { return c.IntelligentEye },
+		supported:    func(m *ModelInfo) bool { return m.MDtct },
+		setUrlValues: func(c *ControlInfo, v url.Values) { v.Set("en_intelligent_eye", encodeBool(c.IntelligentEye)) },
+	},
+}
+
+// decodeAdv decodes the "/" separated special modes reported in adv.
+func (c *ControlInfo) decodeAdv(s string) {
+	c.Powerful = false
+	for _, a := range strings.Split(s, "/") {
+		if a == advPowerful {
+			c.Powerful = true
+		}
+	}
+}
+
+// featuresEnabled returns whether c enables any of the optional features.
+func (c *ControlInfo) featuresEnabled() bool {
+	for _, f := range controlFeatures {
+		if f.enabled(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFeatures returns ErrUnsupported if c enables a feature the model
+// does not support.
+func (m *ModelInfo) checkFeatures(c *ControlInfo) error {
+	for _, f := range controlFeatures {
+		if f.enabled(c) && !f.supported(m) {
+			return &ErrUnsupported{Feature: f.name}
+		}
+	}
+	return nil
+}
+
+// setFeatureUrlValues puts the settings of the features supported by the
+// model into v. m may be nil, in which case no features are set.
+func (c *ControlInfo) setFeatureUrlValues(m *ModelInfo, v url.Values) {
+	if m == nil {
+		return
+	}
+	for _, f := range controlFeatures {
+		if f.supported(m) {
+			f.setUrlValues(c, v)
+		}
+	}
+}
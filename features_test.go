@@ -0,0 +1,53 @@
+package daikin_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/buxtronix/go-daikin"
+	"github.com/buxtronix/go-daikin/daikintest"
+)
+
+// TestSetFeatureWithoutModelInfo checks that optional features are sent,
+// or rejected, even if ModelInfo was not fetched first. The mock model
+// supports intelligent eye, but not powerful mode.
+func TestSetFeatureWithoutModelInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     func(c *daikin.ControlInfo)
+		check   func(c daikin.ControlInfo) bool
+		wantErr string
+	}{
+		{
+			name:  "supported",
+			set:   func(c *daikin.ControlInfo) { c.IntelligentEye = true },
+			check: func(c daikin.ControlInfo) bool { return c.IntelligentEye },
+		},
+		{
+			name:    "unsupported",
+			set:     func(c *daikin.ControlInfo) { c.Powerful = true },
+			check:   func(c daikin.ControlInfo) bool { return !c.Powerful },
+			wantErr: "powerful",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := daikintest.NewMockDevice(t)
+			d := m.Device()
+			c := m.State()
+			tt.set(&c)
+			d.ControlInfo = &c
+			err := d.SetControlInfo()
+			var unsup *daikin.ErrUnsupported
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("SetControlInfo() = %v", err)
+			case tt.wantErr != "" && (!errors.As(err, &unsup) || unsup.Feature != tt.wantErr):
+				t.Fatalf("SetControlInfo() = %v, want ErrUnsupported for %s", err, tt.wantErr)
+			}
+			if !tt.check(m.State()) {
+				t.Errorf("unit state = %+v", m.State())
+			}
+		})
+	}
+}
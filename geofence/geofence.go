@@ -0,0 +1,72 @@
+// Package geofence switches Daikin units between home and away settings
+// based on location. The caller supplies locations, eg from a phone; the
+// package only maps them to control settings.
+package geofence
+
+import (
+	"math"
+	"sync"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+// earthRadius is the mean radius of the earth, in metres.
+const earthRadius = 6371000
+
+// GeoFence is a circular area around home.
+type GeoFence struct {
+	// Lat and Lon are the coordinates of the centre, in degrees.
+	Lat, Lon float64
+	// Radius is the radius of the area, in metres.
+	Radius float64
+}
+
+// Distance returns the distance from the centre of the fence to the
+// given coordinates, in metres.
+func (g GeoFence) Distance(lat, lon float64) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(lat - g.Lat)
+	dLon := rad(lon - g.Lon)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(g.Lat))*math.Cos(rad(lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// Controller maps locations to home or away control settings.
+type Controller struct {
+	// Home is the settings used inside the fence.
+	Home daikin.ControlInfo
+	// Away is the settings used outside the fence.
+	Away daikin.ControlInfo
+	// Fence is the area considered home.
+	Fence GeoFence
+	// Hysteresis is the distance in metres beyond the fence that must be
+	// travelled before leaving home, to avoid switching back and forth at
+	// the boundary.
+	Hysteresis float64
+
+	mu   sync.Mutex
+	home bool
+}
+
+// Check returns the control settings for the given location.
+func (c *Controller) Check(lat, lon float64) daikin.ControlInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	radius := c.Fence.Radius
+	if c.home {
+		radius += c.Hysteresis
+	}
+	c.home = c.Fence.Distance(lat, lon) <= radius
+	if c.home {
+		return c.Home
+	}
+	return c.Away
+}
+
+// IsHome returns whether the last location checked was home.
+func (c *Controller) IsHome() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.home
+}
@@ -0,0 +1,369 @@
+// Package graphql serves Daikin units over GraphQL.
+//
+// The schema is:
+//
+//	type Query {
+//		devices: [Device!]!
+//	}
+//	type Mutation {
+//		setControl(address: String!, input: ControlInput!): ControlInfo
+//	}
+//	type Device {
+//		id: String!
+//		name: String!
+//		address: String!
+//		controlInfo: ControlInfo
+//		sensorInfo: SensorInfo
+//	}
+//	type ControlInfo {
+//		power: String!
+//		mode: String!
+//		temperature: Float!
+//		fan: String!
+//		fanDir: String!
+//		humidity: Int!
+//	}
+//	type SensorInfo {
+//		homeTemp: Float!
+//		outsideTemp: Float!
+//		humidity: Int!
+//	}
+//	input ControlInput {
+//		power: String
+//		mode: String
+//		temperature: Float
+//		fan: String
+//		fanDir: String
+//		humidity: Int
+//	}
+//
+// The control info and sensor info of a device are fetched from the unit
+// when queried. Settings are given by name, eg mode "Heat", and fields
+// omitted from ControlInput are left unchanged.
+package graphql
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/buxtronix/go-daikin"
+	gographql "github.com/graphql-go/graphql"
+)
+
+// resolver resolves queries against a set of units.
+type resolver struct {
+	devices map[string]*daikin.Daikin
+}
+
+// device is a unit, as resolved by the Device type.
+type device struct {
+	id string
+	d  *daikin.Daikin
+}
+
+var controlInfoType = gographql.NewObject(gographql.ObjectConfig{
+	Name: "ControlInfo",
+	Fields: gographql.Fields{
+		"power": &gographql.Field{
+			Type:    gographql.NewNonNull(gographql.String),
+			Resolve: controlField(func(c *daikin.ControlInfo) interface{} { return c.Power.String() }),
+		},
+		"mode": &gographql.Field{
+			Type:    gographql.NewNonNull(gographql.String),
+			Resolve: controlField(func(c *daikin.ControlInfo) interface{} { return c.Mode.String() }),
+		},
+		"temperature": &gographql.Field{
+			Type:    gographql.NewNonNull(gographql.Float),
+			Resolve: controlField(func(c *daikin.ControlInfo) interface{} { return float64(c.Temperature) }),
+		},
+		"fan": &gographql.Field{
+			Type:    gographql.NewNonNull(gographql.String),
+			Resolve: controlField(func(c *daikin.ControlInfo) interface{} { return c.Fan.String() }),
+		},
+		"fanDir": &gographql.Field{
+			Type:    gographql.NewNonNull(gographql.String),
+			Resolve: controlField(func(c *daikin.ControlInfo) interface{} { return c.FanDir.String() }),
+		},
+		"humidity": &gographql.Field{
+			Type:    gographql.NewNonNull(gographql.Int),
+			Resolve: controlField(func(c *daikin.ControlInfo) interface{} { return int(c.Humidity) }),
+		},
+	},
+})
+
+// controlField returns a resolver for a ControlInfo field.
+func controlField(f func(*daikin.ControlInfo) interface{}) gographql.FieldResolveFn {
+	return func(p gographql.ResolveParams) (interface{}, error) {
+		return f(p.Source.(*daikin.ControlInfo)), nil
+	}
+}
+
+var sensorInfoType = gographql.NewObject(gographql.ObjectConfig{
+	Name: "SensorInfo",
+	Fields: gographql.Fields{
+		"homeTemp": &gographql.Field{
+			Type:    gographql.NewNonNull(gographql.Float),
+			Resolve: sensorField(func(s *daikin.SensorInfo) interface{} { return float64(s.HomeTemperature) }),
+		},
+		"outsideTemp": &gographql.Field{
+			Type:    gographql.NewNonNull(gographql.Float),
+			Resolve: sensorField(func(s *daikin.SensorInfo) interface{} { return float64(s.OutsideTemperature) }),
+		},
+		"humidity": &gographql.Field{
+			Type:    gographql.NewNonNull(gographql.Int),
+			Resolve: sensorField(func(s *daikin.SensorInfo) interface{} { return int(s.Humidity) }),
+		},
+	},
+})
+
+// sensorField returns a resolver for a SensorInfo field.
+func sensorField(f func(*daikin.SensorInfo) interface{}) gographql.FieldResolveFn {
+	return func(p gographql.ResolveParams) (interface{}, error) {
+		return f(p.Source.(*daikin.SensorInfo)), nil
+	}
+}
+
+var controlInputType = gographql.NewInputObject(gographql.InputObjectConfig{
+	Name: "ControlInput",
+	Fields: gographql.InputObjectConfigFieldMap{
+		"power":       &gographql.InputObjectFieldConfig{Type: gographql.String},
+		"mode":        &gographql.InputObjectFieldConfig{Type: gographql.String},
+		"temperature": &gographql.InputObjectFieldConfig{Type: gographql.Float},
+		"fan":         &gographql.InputObjectFieldConfig{Type: gographql.String},
+		"fanDir":      &gographql.InputObjectFieldConfig{Type: gographql.String},
+		"humidity":    &gographql.InputObjectFieldConfig{Type: gographql.Int},
+	},
+})
+
+// NewSchema returns the GraphQL schema for the devices, keyed by their id.
+func NewSchema(devices map[string]*daikin.Daikin) (gographql.Schema, error) {
+	r := &resolver{devices: devices}
+	deviceType := gographql.NewObject(gographql.ObjectConfig{
+		Name: "Device",
+		Fields: gographql.Fields{
+			"id": &gographql.Field{
+				Type: gographql.NewNonNull(gographql.String),
+				Resolve: func(p gographql.ResolveParams) (interface{}, error) {
+					return p.Source.(device).id, nil
+				},
+			},
+			"name": &gographql.Field{
+				Type: gographql.NewNonNull(gographql.String),
+				Resolve: func(p gographql.ResolveParams) (interface{}, error) {
+					return p.Source.(device).d.Name.String(), nil
+				},
+			},
+			"address": &gographql.Field{
+				Type: gographql.NewNonNull(gographql.String),
+				Resolve: func(p gographql.ResolveParams) (interface{}, error) {
+					return p.Source.(device).d.Address, nil
+				},
+			},
+			"controlInfo": &gographql.Field{
+				Type:    controlInfoType,
+				Resolve: r.controlInfo,
+			},
+			"sensorInfo": &gographql.Field{
+				Type:    sensorInfoType,
+				Resolve: r.sensorInfo,
+			},
+		},
+	})
+	return gographql.NewSchema(gographql.SchemaConfig{
+		Query: gographql.NewObject(gographql.ObjectConfig{
+			Name: "Query",
+			Fields: gographql.Fields{
+				"devices": &gographql.Field{
+					Type:    gographql.NewNonNull(gographql.NewList(gographql.NewNonNull(deviceType))),
+					Resolve: r.listDevices,
+				},
+			},
+		}),
+		Mutation: gographql.NewObject(gographql.ObjectConfig{
+			Name: "Mutation",
+			Fields: gographql.Fields{
+				"setControl": &gographql.Field{
+					Type: controlInfoType,
+					Args: gographql.FieldConfigArgument{
+						"address": &gographql.ArgumentConfig{Type: gographql.NewNonNull(gographql.String)},
+						"input":   &gographql.ArgumentConfig{Type: gographql.NewNonNull(controlInputType)},
+					},
+					Resolve: r.setControl,
+				},
+			},
+		}),
+	})
+}
+
+func (r *resolver) listDevices(p gographql.ResolveParams) (interface{}, error) {
+	ids := make([]string, 0, len(r.devices))
+	for id := range r.devices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	devs := make([]device, len(ids))
+	for i, id := range ids {
+		devs[i] = device{id: id, d: r.devices[id]}
+	}
+	return devs, nil
+}
+
+func (r *resolver) controlInfo(p gographql.ResolveParams) (interface{}, error) {
+	d := p.Source.(device).d
+	var ci daikin.ControlInfo
+	err := d.WithLock(func() error {
+		if err := d.GetControlInfoContext(p.Context); err != nil {
+			return err
+		}
+		ci = *d.ControlInfo
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ci, nil
+}
+
+func (r *resolver) sensorInfo(p gographql.ResolveParams) (interface{}, error) {
+	d := p.Source.(device).d
+	var si daikin.SensorInfo
+	err := d.WithLock(func() error {
+		if err := d.GetSensorInfoContext(p.Context); err != nil {
+			return err
+		}
+		si = *d.SensorInfo
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &si, nil
+}
+
+// deviceByAddress returns the unit with the given address.
+func (r *resolver) deviceByAddress(addr string) (*daikin.Daikin, bool) {
+	for _, d := range r.devices {
+		if d.Address == addr {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+func (r *resolver) setControl(p gographql.ResolveParams) (interface{}, error) {
+	d, ok := r.deviceByAddress(p.Args["address"].(string))
+	if !ok {
+		return nil, errors.New("unknown device")
+	}
+	ci, err := d.UpdateControlInfo(p.Context, func(c *daikin.ControlInfo) error {
+		return applyInput(c, p.Args["input"].(map[string]interface{}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ci, nil
+}
+
+// applyInput applies the fields set in the ControlInput to ci.
+func applyInput(ci *daikin.ControlInfo, in map[string]interface{}) error {
+	var err error
+	if v, ok := in["power"].(string); ok {
+		if ci.Power, err = daikin.ParsePower(v); err != nil {
+			return err
+		}
+	}
+	if v, ok := in["mode"].(string); ok {
+		if ci.Mode, err = daikin.ParseMode(v); err != nil {
+			return err
+		}
+	}
+	if v, ok := in["temperature"].(float64); ok {
+		ci.Temperature = daikin.Temperature(v)
+	}
+	if v, ok := in["fan"].(string); ok {
+		if ci.Fan, err = daikin.ParseFan(v); err != nil {
+			return err
+		}
+	}
+	if v, ok := in["fanDir"].(string); ok {
+		if ci.FanDir, err = daikin.ParseFanDir(v); err != nil {
+			return err
+		}
+	}
+	if v, ok := in["humidity"].(int); ok {
+		ci.Humidity = daikin.Humidity(v)
+	}
+	return nil
+}
+
+// request is a GraphQL request, as sent by clients over HTTP.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// handler serves GraphQL requests over HTTP.
+type handler struct {
+	schema gographql.Schema
+	token  string
+}
+
+// TokenOption requires requests to authenticate with the given bearer
+// token, in an "Authorization: Bearer <token>" header. Without it, anyone
+// who can reach the handler can change the units' settings.
+func TokenOption(token string) func(*handler) {
+	return func(h *handler) {
+		h.token = token
+	}
+}
+
+// NewHandler returns a handler serving GraphQL requests for the devices,
+// keyed by their id. Requests are POSTed as JSON, with the query in a
+// "query" field. The setControl mutation changes the units' settings, so
+// unless TokenOption is given the handler must only be served on a trusted
+// network.
+func NewHandler(devices map[string]*daikin.Daikin, opts ...func(*handler)) (http.Handler, error) {
+	schema, err := NewSchema(devices)
+	if err != nil {
+		return nil, err
+	}
+	h := &handler{schema: schema}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := []byte(r.Header.Get("Authorization"))
+	if h.token != "" && subtle.ConstantTimeCompare(auth, []byte("Bearer "+h.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "invalid or missing token", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("malformed request: %v", err), http.StatusBadRequest)
+		return
+	}
+	res := gographql.Do(gographql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        r.Context(),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
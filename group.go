@@ -0,0 +1,75 @@
+package daikin
+
+import (
+	"context"
+	"sync"
+)
+
+// Group is a set of units controlled together, eg several units in the
+// same room. Operations are applied to all units concurrently, and do not
+// stop at the first failure.
+type Group struct {
+	// Devices are the units in the group.
+	Devices []*Daikin
+	// Concurrency is the maximum number of units queried concurrently.
+	// Values below 1 are unlimited.
+	Concurrency int
+}
+
+// NewGroup returns a new Group of the given units.
+func NewGroup(devices ...*Daikin) *Group {
+	return &Group{Devices: devices}
+}
+
+// GetControlInfo concurrently gets the control info of all units, keyed by
+// address. If any units failed, their errors are returned in a GroupError
+// along with the info of the others.
+func (g *Group) GetControlInfo(ctx context.Context) (map[string]*ControlInfo, error) {
+	var mu sync.Mutex
+	infos := map[string]*ControlInfo{}
+	errs := forEachDevice(g.Devices, g.Concurrency, func(dev *Daikin) error {
+		if err := dev.GetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		mu.Lock()
+		infos[dev.Address] = dev.ControlInfo
+		mu.Unlock()
+		return nil
+	})
+	if len(errs) > 0 {
+		return infos, GroupError(errs)
+	}
+	return infos, nil
+}
+
+// SetControlInfo concurrently configures all units with the given control
+// settings. It returns the errors of any units which failed, keyed by
+// address.
+func (g *Group) SetControlInfo(ctx context.Context, ci ControlInfo) map[string]error {
+	return forEachDevice(g.Devices, g.Concurrency, func(dev *Daikin) error {
+		c := ci
+		dev.ControlInfo = &c
+		return dev.SetControlInfoContext(ctx)
+	})
+}
+
+// GetSensorInfo concurrently gets the sensor info of all units, keyed by
+// address. If any units failed, their errors are returned in a GroupError
+// along with the info of the others.
+func (g *Group) GetSensorInfo(ctx context.Context) (map[string]*SensorInfo, error) {
+	var mu sync.Mutex
+	infos := map[string]*SensorInfo{}
+	errs := forEachDevice(g.Devices, g.Concurrency, func(dev *Daikin) error {
+		if err := dev.GetSensorInfoContext(ctx); err != nil {
+			return err
+		}
+		mu.Lock()
+		infos[dev.Address] = dev.SensorInfo
+		mu.Unlock()
+		return nil
+	})
+	if len(errs) > 0 {
+		return infos, GroupError(errs)
+	}
+	return infos, nil
+}
@@ -0,0 +1,208 @@
+// Package grpc serves Daikin units over gRPC, using the DaikinService
+// defined in proto/grpcpb/service.proto.
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"sort"
+
+	"github.com/buxtronix/go-daikin"
+	daikinpb "github.com/buxtronix/go-daikin/proto"
+	"github.com/buxtronix/go-daikin/proto/grpcpb"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements DaikinService for a set of units.
+type Server struct {
+	grpcpb.UnimplementedDaikinServiceServer
+
+	devices map[string]*daikin.Daikin
+}
+
+// NewServer returns a DaikinService implementation for the devices, keyed
+// by their id.
+func NewServer(devices map[string]*daikin.Daikin) *Server {
+	return &Server{devices: devices}
+}
+
+// config is the configuration of a gRPC server.
+type config struct {
+	tlsConfig    *tls.Config
+	token        string
+	methodTokens map[string]string
+}
+
+// TLSOption serves over TLS with the given configuration.
+func TLSOption(c *tls.Config) func(*config) {
+	return func(cfg *config) {
+		cfg.tlsConfig = c
+	}
+}
+
+// TokenOption requires calls to authenticate with the given bearer token,
+// sent in "authorization" metadata as "Bearer <token>".
+func TokenOption(token string) func(*config) {
+	return func(cfg *config) {
+		cfg.token = token
+	}
+}
+
+// MethodTokenOption requires calls to the given method, eg
+// "/daikin.DaikinService/SetControl", to authenticate with token instead
+// of the one given by TokenOption.
+func MethodTokenOption(method, token string) func(*config) {
+	return func(cfg *config) {
+		cfg.methodTokens[method] = token
+	}
+}
+
+// NewGRPCServer returns a gRPC server with DaikinService registered for
+// the devices, keyed by their id.
+func NewGRPCServer(devices map[string]*daikin.Daikin, opts ...func(*config)) *gogrpc.Server {
+	cfg := &config{methodTokens: map[string]string{}}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	var sOpts []gogrpc.ServerOption
+	if cfg.tlsConfig != nil {
+		sOpts = append(sOpts, gogrpc.Creds(credentials.NewTLS(cfg.tlsConfig)))
+	}
+	sOpts = append(sOpts, gogrpc.UnaryInterceptor(cfg.authenticate))
+	s := gogrpc.NewServer(sOpts...)
+	grpcpb.RegisterDaikinServiceServer(s, NewServer(devices))
+	return s
+}
+
+// authenticate checks the token required for the method, if any.
+func (cfg *config) authenticate(ctx context.Context, req interface{}, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (interface{}, error) {
+	token, ok := cfg.methodTokens[info.FullMethod]
+	if !ok {
+		token = cfg.token
+	}
+	if token == "" {
+		return handler(ctx, req)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		if subtle.ConstantTimeCompare([]byte(v), []byte("Bearer "+token)) == 1 {
+			return handler(ctx, req)
+		}
+	}
+	return nil, status.Error(codes.Unauthenticated, "invalid or missing token")
+}
+
+// ListDevices implements DaikinService.
+func (s *Server) ListDevices(ctx context.Context, req *daikinpb.ListDevicesRequest) (*daikinpb.ListDevicesResponse, error) {
+	resp := &daikinpb.ListDevicesResponse{}
+	for id, d := range s.devices {
+		d.WithLock(func() error {
+			resp.Devices = append(resp.Devices, &daikinpb.Device{
+				Id:      id,
+				Address: d.Address,
+				Name:    d.Name.String(),
+			})
+			return nil
+		})
+	}
+	sort.Slice(resp.Devices, func(i, j int) bool { return resp.Devices[i].Id < resp.Devices[j].Id })
+	return resp, nil
+}
+
+// GetControl implements DaikinService.
+func (s *Server) GetControl(ctx context.Context, req *daikinpb.GetControlRequest) (*daikinpb.ControlInfo, error) {
+	d, err := s.device(req.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	var c *daikinpb.ControlInfo
+	err = d.WithLock(func() error {
+		if err := d.GetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		c = d.ControlInfo.ToProto()
+		return nil
+	})
+	if err != nil {
+		return nil, deviceError(err)
+	}
+	return c, nil
+}
+
+// SetControl implements DaikinService.
+func (s *Server) SetControl(ctx context.Context, req *daikinpb.SetControlRequest) (*daikinpb.ControlInfo, error) {
+	d, err := s.device(req.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetControl() == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing control")
+	}
+	ci := &daikin.ControlInfo{}
+	if err := ci.FromProto(req.GetControl()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	updated, err := d.UpdateControlInfo(ctx, func(c *daikin.ControlInfo) error {
+		*c = *ci
+		return nil
+	})
+	if err != nil {
+		return nil, deviceError(err)
+	}
+	return updated.ToProto(), nil
+}
+
+// GetSensor implements DaikinService.
+func (s *Server) GetSensor(ctx context.Context, req *daikinpb.GetSensorRequest) (*daikinpb.SensorInfo, error) {
+	d, err := s.device(req.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	var si *daikinpb.SensorInfo
+	err = d.WithLock(func() error {
+		if err := d.GetSensorInfoContext(ctx); err != nil {
+			return err
+		}
+		si = d.SensorInfo.ToProto()
+		return nil
+	})
+	if err != nil {
+		return nil, deviceError(err)
+	}
+	return si, nil
+}
+
+func (s *Server) device(id string) (*daikin.Daikin, error) {
+	d, ok := s.devices[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown device %q", id)
+	}
+	return d, nil
+}
+
+// deviceError converts an error from a device request to a gRPC status.
+func deviceError(err error) error {
+	var (
+		devErr   *daikin.DeviceError
+		rangeErr *daikin.ErrTemperatureOutOfRange
+		unsupErr *daikin.ErrUnsupported
+	)
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.As(err, &devErr), errors.As(err, &rangeErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &unsupErr):
+		return status.Error(codes.Unimplemented, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
@@ -0,0 +1,88 @@
+package daikin
+
+import "sync"
+
+// hooks are the change callbacks registered on a unit.
+type hooks struct {
+	mu     sync.Mutex
+	state  []func(d *Daikin, prev, curr ControlInfo)
+	sensor []func(d *Daikin, prev, curr SensorInfo)
+	// buffers are the buffers attached with AttachBuffer.
+	buffers []*RingBuffer
+	// anomaly are the detectors registered with OnAnomaly.
+	anomaly []*anomalyHook
+	// alerts are the thresholds registered with SetAlertThresholds.
+	alerts []*alertThreshold
+}
+
+// OnStateChange registers a callback, called when GetControlInfo finds the
+// control settings differ from those previously fetched. Multiple callbacks
+// may be registered, and are called in order. Callbacks must not fetch
+// info from the same unit, as fetches wait for them. It is safe to call
+// concurrently.
+func (d *Daikin) OnStateChange(fn func(d *Daikin, prev, curr ControlInfo)) {
+	d.hooks.mu.Lock()
+	defer d.hooks.mu.Unlock()
+	d.hooks.state = append(d.hooks.state, fn)
+}
+
+// OnSensorChange registers a callback, called when GetSensorInfo finds the
+// sensor values differ from those previously fetched. Multiple callbacks
+// may be registered, and are called in order. Callbacks must not fetch
+// info from the same unit, as fetches wait for them. It is safe to call
+// concurrently.
+func (d *Daikin) OnSensorChange(fn func(d *Daikin, prev, curr SensorInfo)) {
+	d.hooks.mu.Lock()
+	defer d.hooks.mu.Unlock()
+	d.hooks.sensor = append(d.hooks.sensor, fn)
+}
+
+// controlFetched calls the state change callbacks if curr differs from
+// prev, which may be nil if not previously fetched.
+func (d *Daikin) controlFetched(prev, curr *ControlInfo) {
+	if prev == nil || len(Diff(*prev, *curr)) == 0 {
+		return
+	}
+	d.hooks.mu.Lock()
+	fns := d.hooks.state
+	d.hooks.mu.Unlock()
+	for _, fn := range fns {
+		fn(d, *prev, *curr)
+	}
+}
+
+// sensorFetched records curr in the attached buffers, checks it for
+// anomalies and alerts, and calls the sensor change callbacks if it
+// differs from prev, which may be nil if not previously fetched.
+func (d *Daikin) sensorFetched(prev, curr *SensorInfo) {
+	d.record(curr)
+	d.hooks.mu.Lock()
+	anomaly := d.hooks.anomaly
+	d.hooks.mu.Unlock()
+	for _, h := range anomaly {
+		h.check(d, curr)
+	}
+	d.checkAlerts(curr)
+	if prev == nil || prev.equal(curr) {
+		return
+	}
+	d.hooks.mu.Lock()
+	fns := d.hooks.sensor
+	d.hooks.mu.Unlock()
+	for _, fn := range fns {
+		fn(d, *prev, *curr)
+	}
+}
+
+// equal returns whether s and o have the same values.
+func (s *SensorInfo) equal(o *SensorInfo) bool {
+	intEqual := func(a, b *int) bool {
+		return a == nil && b == nil || a != nil && b != nil && *a == *b
+	}
+	return s.HomeTemperature == o.HomeTemperature &&
+		s.OutsideTemperature == o.OutsideTemperature &&
+		s.Humidity == o.Humidity &&
+		s.FilterDirty == o.FilterDirty &&
+		intEqual(s.CompressorFrequency, o.CompressorFrequency) &&
+		intEqual(s.InstantPower, o.InstantPower)
+}
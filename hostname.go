@@ -0,0 +1,94 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// defaultResolveInterval is how long a resolved hostname is cached if
+// ResolveInterval is not set.
+const defaultResolveInterval = 5 * time.Minute
+
+// AddressNameOption adds a device by hostname, eg "lounge-ac.lan", which is
+// resolved to its first IPv4 address. The device is keyed by that address
+// in Devices. The hostname is resolved again before requests once
+// ResolveInterval has passed, so the device is followed if DHCP assigns it
+// a new address. Hostnames which cannot be resolved when the network is
+// created are logged and skipped. It may be given multiple times.
+func AddressNameOption(hostname string) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.hostnames = append(d.hostnames, hostname)
+		d.PollCount = 0
+	}
+}
+
+// lookupIPv4 returns the first IPv4 address of the host.
+func lookupIPv4(ctx context.Context, host string) (string, error) {
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range addrs {
+		if ip := net.ParseIP(a); ip != nil && ip.To4() != nil {
+			return ip.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no IPv4 address for host: %s", host)
+}
+
+// addHostnames adds the devices given by AddressNameOption. Hostnames
+// which cannot be resolved are logged and skipped.
+func (d *DaikinNetwork) addHostnames(ctx context.Context) {
+	for _, h := range d.hostnames {
+		addr, err := lookupIPv4(ctx, h)
+		if err != nil {
+			d.log().Warn("cannot resolve hostname, skipping", "hostname", h, "err", err)
+			continue
+		}
+		dev := d.newDevice(addr)
+		dev.Hostname = h
+		dev.resolved = time.Now()
+		d.Devices[addr] = dev
+	}
+}
+
+// resolve updates Address from Hostname, if set and the last resolution
+// has expired, and returns the address to use for a request. If
+// resolution fails, the previous address is kept.
+func (d *Daikin) resolve(ctx context.Context) string {
+	d.resolveMu.Lock()
+	defer d.resolveMu.Unlock()
+	if d.Hostname == "" {
+		return d.Address
+	}
+	interval := d.ResolveInterval
+	if interval <= 0 {
+		interval = defaultResolveInterval
+	}
+	if time.Since(d.resolved) < interval {
+		return d.Address
+	}
+	addr, err := lookupIPv4(ctx, d.Hostname)
+	if err != nil {
+		return d.Address
+	}
+	d.Address = addr
+	d.resolved = time.Now()
+	return addr
+}
+
+// address returns Address, safe for use concurrently with resolve.
+func (d *Daikin) address() string {
+	d.resolveMu.Lock()
+	defer d.resolveMu.Unlock()
+	return d.Address
+}
+
+// setAddress sets Address, safe for use concurrently with resolve.
+func (d *Daikin) setAddress(addr string) {
+	d.resolveMu.Lock()
+	defer d.resolveMu.Unlock()
+	d.Address = addr
+}
@@ -0,0 +1,55 @@
+package daikin
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// influxTagEscaper escapes InfluxDB line protocol tag keys and values.
+var influxTagEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+// influxStringEscaper escapes InfluxDB line protocol string field values.
+var influxStringEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`)
+
+// FormatInfluxLine returns the unit's sensor and control state as an
+// InfluxDB line protocol record for the measurement daikin_state, tagged
+// with the device name and address. Fields for state that has not been
+// fetched are omitted; if there are no fields, it returns "".
+func FormatInfluxLine(d *Daikin, timestamp time.Time) string {
+	var fields []string
+	float := func(k string, t Temperature) {
+		fields = append(fields, k+"="+strconv.FormatFloat(float64(t), 'f', -1, 64))
+	}
+	integer := func(k string, v int) {
+		fields = append(fields, k+"="+strconv.Itoa(v)+"i")
+	}
+	if s := d.SensorInfo; s != nil {
+		float("indoor_temperature", s.HomeTemperature)
+		float("outdoor_temperature", s.OutsideTemperature)
+		if s.Humidity >= 0 {
+			integer("humidity", int(s.Humidity))
+		}
+	}
+	if c := d.ControlInfo; c != nil {
+		integer("power", int(c.Power))
+		integer("mode", int(c.Mode))
+		fields = append(fields, `fan="`+influxStringEscaper.Replace(c.Fan.String())+`"`)
+		float("set_temperature", c.Temperature)
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("daikin_state")
+	if d.Address != "" {
+		b.WriteString(",address=" + influxTagEscaper.Replace(d.Address))
+	}
+	if name := d.Name.String(); name != "" {
+		b.WriteString(",device=" + influxTagEscaper.Replace(name))
+	}
+	b.WriteString(" " + strings.Join(fields, ",") + " ")
+	b.WriteString(strconv.FormatInt(timestamp.UnixNano(), 10))
+	return b.String()
+}
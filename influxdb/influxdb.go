@@ -0,0 +1,162 @@
+// Package influxdb writes the state of Daikin units to InfluxDB v2.
+//
+// Each unit is written as a point tagged with its address and name, with
+// the same fields as daikin.FormatInfluxLine.
+package influxdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/buxtronix/go-daikin"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	influxhttp "github.com/influxdata/influxdb-client-go/v2/api/http"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// Writer writes the state of a set of units to an InfluxDB bucket.
+type Writer struct {
+	client      influxdb2.Client
+	writeAPI    api.WriteAPIBlocking
+	devices     []*daikin.Daikin
+	measurement string
+	tags        map[string]string
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+// MeasurementOption configures the measurement name. The default is
+// "daikin_state".
+func MeasurementOption(name string) func(*Writer) {
+	return func(w *Writer) {
+		w.measurement = name
+	}
+}
+
+// TagOption adds a tag to all points, eg "room" or "floor". It may be given
+// multiple times.
+func TagOption(key, value string) func(*Writer) {
+	return func(w *Writer) {
+		w.tags[key] = value
+	}
+}
+
+// RetryOption configures writes to retry transient failures, up to
+// maxAttempts in total. The delay between attempts starts at initialDelay
+// and doubles after each retry. By default writes are attempted 3 times,
+// starting with a 1 second delay.
+func RetryOption(maxAttempts int, initialDelay time.Duration) func(*Writer) {
+	return func(w *Writer) {
+		w.maxAttempts = maxAttempts
+		w.retryDelay = initialDelay
+	}
+}
+
+// NewWriter returns a Writer for the devices, writing to the bucket of org
+// on the InfluxDB server at url, authenticating with token.
+func NewWriter(url, org, bucket, token string, devices []*daikin.Daikin, opts ...func(*Writer)) *Writer {
+	client := influxdb2.NewClient(url, token)
+	w := &Writer{
+		client:      client,
+		writeAPI:    client.WriteAPIBlocking(org, bucket),
+		devices:     devices,
+		measurement: "daikin_state",
+		tags:        map[string]string{},
+		maxAttempts: 3,
+		retryDelay:  time.Second,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Close releases the resources of the writer.
+func (w *Writer) Close() {
+	w.client.Close()
+}
+
+// point fetches the state of the unit, and returns it as a point.
+func (w *Writer) point(ctx context.Context, d *daikin.Daikin, ts time.Time) (*write.Point, error) {
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return nil, err
+	}
+	if err := d.GetSensorInfoContext(ctx); err != nil {
+		return nil, err
+	}
+	tags := map[string]string{"address": d.Address}
+	if name := d.Name.String(); name != "" {
+		tags["device"] = name
+	}
+	for k, v := range w.tags {
+		tags[k] = v
+	}
+	s, c := d.SensorInfo, d.ControlInfo
+	fields := map[string]interface{}{
+		"indoor_temperature":  float64(s.HomeTemperature),
+		"outdoor_temperature": float64(s.OutsideTemperature),
+		"power":               int(c.Power),
+		"mode":                int(c.Mode),
+		"fan":                 c.Fan.String(),
+		"set_temperature":     float64(c.Temperature),
+	}
+	if s.Humidity >= 0 {
+		fields["humidity"] = int(s.Humidity)
+	}
+	return write.NewPoint(w.measurement, tags, fields, ts), nil
+}
+
+// transient returns whether err from a write may succeed if retried.
+func transient(err error) bool {
+	var herr *influxhttp.Error
+	if !errors.As(err, &herr) {
+		return false
+	}
+	return herr.StatusCode == 0 || herr.StatusCode == http.StatusTooManyRequests || herr.StatusCode >= 500
+}
+
+// WriteAll fetches the state of all units and writes it as a single batch.
+// If any units could not be queried, the others are written and their
+// errors returned in a daikin.GroupError.
+func (w *Writer) WriteAll(ctx context.Context) error {
+	now := time.Now()
+	var points []*write.Point
+	errs := daikin.GroupError{}
+	for _, d := range w.devices {
+		p, err := w.point(ctx, d, now)
+		if err != nil {
+			errs[d.Address] = err
+			continue
+		}
+		points = append(points, p)
+	}
+	if len(points) > 0 {
+		if err := w.write(ctx, points); err != nil {
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// write writes the points, retrying transient failures.
+func (w *Writer) write(ctx context.Context, points []*write.Point) error {
+	delay := w.retryDelay
+	for attempt := 1; ; attempt++ {
+		err := w.writeAPI.WritePoint(ctx, points...)
+		if err == nil || attempt >= w.maxAttempts || !transient(err) {
+			return err
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
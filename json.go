@@ -0,0 +1,166 @@
+package daikin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// unmarshalName decodes a JSON string, for enum types that marshal as their
+// human-readable name.
+func unmarshalName(data []byte, typ string) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("%s must be a string: %v", typ, err)
+	}
+	return s, nil
+}
+
+// MarshalJSON encodes the power status as its name, eg "On".
+func (p Power) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON decodes the power status from its name.
+func (p *Power) UnmarshalJSON(data []byte) error {
+	s, err := unmarshalName(data, "power")
+	if err != nil {
+		return err
+	}
+	for k, v := range powerMap {
+		if strings.EqualFold(v, s) {
+			*p = k
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown power value: %s", s)
+}
+
+// MarshalJSON encodes the mode as its name, eg "Heat".
+func (m Mode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON decodes the mode from its name or protocol value.
+func (m *Mode) UnmarshalJSON(data []byte) error {
+	s, err := unmarshalName(data, "mode")
+	if err != nil {
+		return err
+	}
+	v, err := ParseMode(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// MarshalJSON encodes the fan speed as its name, eg "Silent".
+func (f Fan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// UnmarshalJSON decodes the fan speed from its name or protocol value.
+func (f *Fan) UnmarshalJSON(data []byte) error {
+	s, err := unmarshalName(data, "fan")
+	if err != nil {
+		return err
+	}
+	v, err := ParseFan(s)
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// MarshalJSON encodes the louvre setting as its name, eg "Vertical".
+func (f FanDir) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// UnmarshalJSON decodes the louvre setting from its name or protocol value.
+func (f *FanDir) UnmarshalJSON(data []byte) error {
+	s, err := unmarshalName(data, "fan_dir")
+	if err != nil {
+		return err
+	}
+	v, err := ParseFanDir(s)
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// daikinJSON is the JSON representation of a Daikin.
+type daikinJSON struct {
+	Address     string       `json:"address"`
+	Name        Name         `json:"name,omitempty"`
+	ControlInfo *ControlInfo `json:"control_info,omitempty"`
+	SensorInfo  *SensorInfo  `json:"sensor_info,omitempty"`
+}
+
+// MarshalJSON encodes the unit address, name and current state.
+func (d *Daikin) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&daikinJSON{
+		Address:     d.Address,
+		Name:        d.Name,
+		ControlInfo: d.ControlInfo,
+		SensorInfo:  d.SensorInfo,
+	})
+}
+
+// UnmarshalJSON decodes the unit address, name and state. Other fields
+// are left unchanged.
+func (d *Daikin) UnmarshalJSON(data []byte) error {
+	var dj daikinJSON
+	if err := json.Unmarshal(data, &dj); err != nil {
+		return err
+	}
+	d.Address = dj.Address
+	d.Name = dj.Name
+	d.ControlInfo = dj.ControlInfo
+	d.SensorInfo = dj.SensorInfo
+	return nil
+}
+
+// RestoreFromJSON concurrently configures the devices on the network with
+// control settings saved as a JSON array of devices, as encoded by
+// MarshalJSON. Saved devices are matched by address, and those without
+// control settings are skipped. It returns the errors of any devices which
+// failed or are not on the network, keyed by address. An error decoding
+// the input is keyed by the empty address.
+func RestoreFromJSON(ctx context.Context, net *DaikinNetwork, r io.Reader) map[string]error {
+	var saved []daikinJSON
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return map[string]error{"": err}
+	}
+	errs := map[string]error{}
+	snaps := map[string]ControlInfo{}
+	var devices []*Daikin
+	for _, s := range saved {
+		if s.ControlInfo == nil {
+			continue
+		}
+		net.mu.RLock()
+		dev, ok := net.Devices[s.Address]
+		net.mu.RUnlock()
+		if !ok {
+			errs[s.Address] = &ErrUnknownValue{Type: "address", Value: s.Address}
+			continue
+		}
+		if _, ok := snaps[s.Address]; !ok {
+			devices = append(devices, dev)
+		}
+		snaps[s.Address] = *s.ControlInfo
+	}
+	for addr, err := range forEachDevice(devices, net.Concurrency, func(dev *Daikin) error {
+		return dev.RestoreSnapshot(ctx, snaps[dev.Address])
+	}) {
+		errs[addr] = err
+	}
+	return errs
+}
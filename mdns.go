@@ -0,0 +1,56 @@
+package daikin
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/hashicorp/mdns"
+)
+
+// mdnsService is the mDNS service type advertised by Daikin Wifi modules.
+const mdnsService = "_daikin._tcp"
+
+// DiscovermDNS discovers devices advertising themselves via mDNS, as an
+// alternative to broadcast discovery with Discover. Devices found are added
+// to Devices. The query runs for PollInterval, or until ctx is done.
+func (d *DaikinNetwork) DiscovermDNS(ctx context.Context) error {
+	params := mdns.DefaultParams(mdnsService)
+	params.DisableIPv6 = true
+	params.Timeout = d.PollInterval
+	if dl, ok := ctx.Deadline(); ok && time.Until(dl) < params.Timeout {
+		params.Timeout = time.Until(dl)
+	}
+	if names := d.interfaces(); len(names) > 0 {
+		ifi, err := net.InterfaceByName(names[0])
+		if err != nil {
+			return err
+		}
+		params.Interface = ifi
+	}
+	// Query does not block sending entries, so buffer them.
+	entries := make(chan *mdns.ServiceEntry, 32)
+	params.Entries = entries
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- mdns.Query(params)
+		close(entries)
+	}()
+	for {
+		select {
+		case e, ok := <-entries:
+			if !ok {
+				return <-errc
+			}
+			if e.AddrV4 == nil {
+				continue
+			}
+			ip := e.AddrV4.String()
+			d.log().Info("mDNS: found device", "name", e.Name, "address", ip)
+			d.addFound(ip)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
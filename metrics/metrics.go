@@ -0,0 +1,90 @@
+// Package metrics exports the state of Daikin units as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"github.com/buxtronix/go-daikin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var labels = []string{"name", "address"}
+
+var (
+	upDesc = prometheus.NewDesc("daikin_up",
+		"Whether the unit could be queried.", labels, nil)
+	indoorTempDesc = prometheus.NewDesc("daikin_indoor_temperature_celsius",
+		"Indoor temperature.", labels, nil)
+	outdoorTempDesc = prometheus.NewDesc("daikin_outdoor_temperature_celsius",
+		"Outdoor temperature.", labels, nil)
+	humidityDesc = prometheus.NewDesc("daikin_indoor_humidity_percent",
+		"Indoor humidity.", labels, nil)
+	setTempDesc = prometheus.NewDesc("daikin_set_temperature_celsius",
+		"Set temperature.", labels, nil)
+	powerDesc = prometheus.NewDesc("daikin_power_state",
+		"Power state (0 off, 1 on).", labels, nil)
+	modeDesc = prometheus.NewDesc("daikin_mode",
+		"Operating mode protocol value (0, 1, 7 auto, 2 dehumidify, 3 cool, 4 heat, 6 fan).", labels, nil)
+	fanDesc = prometheus.NewDesc("daikin_fan_speed",
+		"Fan speed (1-5, 0 silent, -1 auto).", labels, nil)
+)
+
+type collector struct {
+	devices []*daikin.Daikin
+}
+
+// NewCollector returns a Collector which queries the given devices on each
+// scrape. A device which can't be queried reports only daikin_up as 0.
+func NewCollector(devices []*daikin.Daikin) prometheus.Collector {
+	return &collector{devices: devices}
+}
+
+// Describe implements prometheus.Collector.
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{upDesc, indoorTempDesc, outdoorTempDesc, humidityDesc, setTempDesc, powerDesc, modeDesc, fanDesc} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	var wg sync.WaitGroup
+	for _, d := range c.devices {
+		wg.Add(1)
+		go func(d *daikin.Daikin) {
+			defer wg.Done()
+			collectDevice(ch, d)
+		}(d)
+	}
+	wg.Wait()
+}
+
+func collectDevice(ch chan<- prometheus.Metric, d *daikin.Daikin) {
+	ctx := context.Background()
+	lv := []string{d.Name.String(), d.Address}
+	gauge := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, lv...)
+	}
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		gauge(upDesc, 0)
+		return
+	}
+	if err := d.GetSensorInfoContext(ctx); err != nil {
+		gauge(upDesc, 0)
+		return
+	}
+	gauge(upDesc, 1)
+	s, ci := d.SensorInfo, d.ControlInfo
+	gauge(indoorTempDesc, float64(s.HomeTemperature))
+	gauge(outdoorTempDesc, float64(s.OutsideTemperature))
+	if s.Humidity >= 0 {
+		gauge(humidityDesc, float64(s.Humidity))
+	}
+	gauge(setTempDesc, float64(ci.Temperature))
+	gauge(powerDesc, float64(ci.Power))
+	gauge(modeDesc, float64(ci.Mode))
+	if v, ok := ci.Fan.Level(); ok {
+		gauge(fanDesc, float64(v))
+	}
+}
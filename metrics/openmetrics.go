@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/buxtronix/go-daikin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/proto"
+)
+
+// units are the OpenMetrics units of metrics, which are suffixed to their
+// names.
+var units = map[string]string{
+	"daikin_indoor_temperature_celsius":  "celsius",
+	"daikin_outdoor_temperature_celsius": "celsius",
+	"daikin_indoor_humidity_percent":     "percent",
+	"daikin_set_temperature_celsius":     "celsius",
+}
+
+// OpenMetricsHandler returns a handler serving the metrics of the given
+// devices in the OpenMetrics 1.0.0 text format, including UNIT metadata.
+// The devices are queried on each request, as for NewCollector.
+func OpenMetricsHandler(devices []*daikin.Daikin) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewCollector(devices))
+	format := expfmt.NewFormat(expfmt.TypeOpenMetrics)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mfs, err := reg.Gather()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", string(format))
+		enc := expfmt.NewEncoder(w, format, expfmt.WithUnit())
+		for _, mf := range mfs {
+			if u, ok := units[mf.GetName()]; ok {
+				mf.Unit = proto.String(u)
+			}
+			if err := enc.Encode(mf); err != nil {
+				return
+			}
+		}
+		if c, ok := enc.(expfmt.Closer); ok {
+			c.Close()
+		}
+	})
+}
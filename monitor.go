@@ -0,0 +1,113 @@
+package daikin
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ConnectionEvent is a change in the reachability of a unit.
+type ConnectionEvent struct {
+	// Online is whether the unit is reachable.
+	Online bool
+	// Latency is the response time of the request which found the unit
+	// reachable. It is zero when offline.
+	Latency time.Duration
+}
+
+// ConnectionMonitor detects when a unit becomes unreachable, as the Wifi
+// modules often do briefly on congested networks.
+type ConnectionMonitor struct {
+	// Device is the unit to monitor.
+	Device *Daikin
+	// Interval is the time between requests to the unit. Each request
+	// times out after Interval. If not positive, ten seconds is used.
+	Interval time.Duration
+	// MaxMissed is the number of consecutive failed requests after which
+	// the unit is considered offline. Values below 1 are treated as 1.
+	MaxMissed int
+	// Logger logs the changes. If nil, slog.Default() is used.
+	Logger *slog.Logger
+}
+
+// defaultMonitorInterval is the Interval used if it is not positive.
+const defaultMonitorInterval = 10 * time.Second
+
+// interval returns the time between requests.
+func (m *ConnectionMonitor) interval() time.Duration {
+	if m.Interval <= 0 {
+		return defaultMonitorInterval
+	}
+	return m.Interval
+}
+
+// log returns the monitor's logger.
+func (m *ConnectionMonitor) log() *slog.Logger {
+	if m.Logger == nil {
+		return slog.Default()
+	}
+	return m.Logger
+}
+
+// Run requests the basic info of the unit immediately and then every
+// Interval, sending an event to the returned channel when the unit is
+// first found online or offline, and whenever that changes. It returns
+// immediately, and monitoring stops when ctx is done, which closes the
+// channel. Each change is logged.
+func (m *ConnectionMonitor) Run(ctx context.Context) <-chan ConnectionEvent {
+	events := make(chan ConnectionEvent)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(m.interval())
+		defer ticker.Stop()
+		var (
+			known, online bool
+			missed        int
+		)
+		for {
+			latency, err := m.probe(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			var ev *ConnectionEvent
+			if err == nil {
+				missed = 0
+				if !known || !online {
+					m.log().Info("unit online", "address", m.Device.Address, "latency", latency)
+					ev = &ConnectionEvent{Online: true, Latency: latency}
+				}
+			} else {
+				missed++
+				if (!known || online) && missed >= max(m.MaxMissed, 1) {
+					m.log().Warn("unit offline", "address", m.Device.Address, "missed", missed, "err", err)
+					ev = &ConnectionEvent{Online: false}
+				}
+			}
+			if ev != nil {
+				known, online = true, ev.Online
+				select {
+				case events <- *ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// probe requests the basic info of the unit, returning the response time.
+func (m *ConnectionMonitor) probe(ctx context.Context) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.interval())
+	defer cancel()
+	start := time.Now()
+	if err := m.Device.GetBasicInfoContext(ctx); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
@@ -0,0 +1,160 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/buxtronix/go-daikin"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// DefaultDiscoveryPrefix is the default Home Assistant discovery prefix.
+const DefaultDiscoveryPrefix = "homeassistant"
+
+// Templates mapping between Daikin state and Home Assistant climate modes.
+const (
+	haModeStateTemplate = "{% set m = {'Auto': 'auto', 'Cool': 'cool', 'Heat': 'heat', 'Dehumidify': 'dry', 'Fan': 'fan_only'} %}" +
+		"{{ 'off' if value_json.control_info.power == 'Off' else m.get(value_json.control_info.mode, 'auto') }}"
+	haModeCommandTemplate = "{% set m = {'auto': 'Auto', 'cool': 'Cool', 'heat': 'Heat', 'dry': 'Dehumidify', 'fan_only': 'Fan'} %}" +
+		"{% if value == 'off' %}{\"power\": \"Off\"}{% else %}{\"power\": \"On\", \"mode\": \"{{ m[value] }}\"}{% endif %}"
+)
+
+type haDevice struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+	Model        string   `json:"model,omitempty"`
+	SWVersion    string   `json:"sw_version,omitempty"`
+}
+
+// haClimate is a Home Assistant MQTT climate discovery payload.
+type haClimate struct {
+	Name     string   `json:"name"`
+	UniqueID string   `json:"unique_id"`
+	Device   haDevice `json:"device"`
+
+	Modes                []string `json:"modes"`
+	ModeStateTopic       string   `json:"mode_state_topic"`
+	ModeStateTemplate    string   `json:"mode_state_template"`
+	ModeCommandTopic     string   `json:"mode_command_topic"`
+	ModeCommandTemplate  string   `json:"mode_command_template"`
+	FanModes             []string `json:"fan_modes"`
+	FanModeStateTopic    string   `json:"fan_mode_state_topic"`
+	FanModeStateTemplate string   `json:"fan_mode_state_template"`
+	FanModeCommandTopic  string   `json:"fan_mode_command_topic"`
+	FanModeCommandTmpl   string   `json:"fan_mode_command_template"`
+
+	TemperatureStateTopic      string `json:"temperature_state_topic"`
+	TemperatureStateTemplate   string `json:"temperature_state_template"`
+	TemperatureCommandTopic    string `json:"temperature_command_topic"`
+	TemperatureCommandTemplate string `json:"temperature_command_template"`
+	CurrentTemperatureTopic    string `json:"current_temperature_topic"`
+	CurrentTemperatureTemplate string `json:"current_temperature_template"`
+
+	TemperatureUnit string  `json:"temperature_unit"`
+	Precision       float64 `json:"precision"`
+	TempStep        float64 `json:"temp_step"`
+	MinTemp         float64 `json:"min_temp"`
+	MaxTemp         float64 `json:"max_temp"`
+}
+
+// uniqueID returns a stable identifier for the unit, preferring its MAC.
+func uniqueID(d *daikin.Daikin) string {
+	if d.BasicInfo != nil && d.BasicInfo.MAC != "" {
+		return "daikin_" + d.BasicInfo.MAC
+	}
+	return "daikin_" + DeviceID(d)
+}
+
+// HomeAssistantDiscoveryPayload returns the Home Assistant MQTT discovery
+// payload for a climate entity controlling the unit, whose state is
+// published by a Publisher using topicPrefix. If BasicInfo has been fetched
+// the MAC address is used as the unique ID.
+func HomeAssistantDiscoveryPayload(d *daikin.Daikin, topicPrefix string) ([]byte, error) {
+	if topicPrefix == "" {
+		topicPrefix = DefaultTopicPrefix
+	}
+	state, set := topic(topicPrefix, d, "state"), topic(topicPrefix, d, "set")
+	name := d.Name.String()
+	if name == "" {
+		name = d.Address
+	}
+	dev := haDevice{
+		Identifiers:  []string{uniqueID(d)},
+		Name:         name,
+		Manufacturer: "Daikin",
+	}
+	if d.ModelInfo != nil {
+		dev.Model = d.ModelInfo.Model
+	}
+	if d.BasicInfo != nil {
+		dev.SWVersion = d.BasicInfo.Version
+	}
+	return json.Marshal(&haClimate{
+		Name:     name,
+		UniqueID: uniqueID(d),
+		Device:   dev,
+
+		Modes:                []string{"off", "auto", "cool", "heat", "dry", "fan_only"},
+		ModeStateTopic:       state,
+		ModeStateTemplate:    haModeStateTemplate,
+		ModeCommandTopic:     set,
+		ModeCommandTemplate:  haModeCommandTemplate,
+		FanModes:             []string{"auto", "silent", "1", "2", "3", "4", "5"},
+		FanModeStateTopic:    state,
+		FanModeStateTemplate: "{{ value_json.control_info.fan | lower }}",
+		FanModeCommandTopic:  set,
+		FanModeCommandTmpl:   `{"fan": "{{ value }}"}`,
+
+		TemperatureStateTopic:      state,
+		TemperatureStateTemplate:   "{{ value_json.control_info.temperature }}",
+		TemperatureCommandTopic:    set,
+		TemperatureCommandTemplate: `{"temperature": {{ value }}}`,
+		CurrentTemperatureTopic:    state,
+		CurrentTemperatureTemplate: "{{ value_json.sensor_info.home_temperature }}",
+
+		TemperatureUnit: "C",
+		Precision:       0.5,
+		TempStep:        0.5,
+		MinTemp:         10,
+		MaxTemp:         32,
+	})
+}
+
+// discoveryTopic returns the Home Assistant discovery topic for the unit.
+func discoveryTopic(discoveryPrefix string, d *daikin.Daikin) string {
+	if discoveryPrefix == "" {
+		discoveryPrefix = DefaultDiscoveryPrefix
+	}
+	return fmt.Sprintf("%s/climate/%s/config", discoveryPrefix, uniqueID(d))
+}
+
+// publishDiscovery publishes the retained discovery payload for the unit.
+func publishDiscovery(client paho.Client, d *daikin.Daikin, discoveryPrefix, topicPrefix string) error {
+	b, err := HomeAssistantDiscoveryPayload(d, topicPrefix)
+	if err != nil {
+		return err
+	}
+	t := client.Publish(discoveryTopic(discoveryPrefix, d), 1, true, b)
+	t.Wait()
+	return t.Error()
+}
+
+// PublishDiscovery publishes the Home Assistant discovery payload for the
+// unit under the discovery prefix, for state published under
+// DefaultTopicPrefix.
+func PublishDiscovery(client paho.Client, d *daikin.Daikin, prefix string) error {
+	return publishDiscovery(client, d, prefix, DefaultTopicPrefix)
+}
+
+// PublishDiscovery publishes the Home Assistant discovery payloads for all
+// of the Publisher's units under the discovery prefix. The client must be
+// connected; set DiscoveryPrefix to publish them from Run.
+func (p *Publisher) PublishDiscovery(discoveryPrefix string) error {
+	for _, d := range p.devices {
+		if err := publishDiscovery(p.client, d, discoveryPrefix, p.prefix); err != nil {
+			return fmt.Errorf("%s: %v", d.Address, err)
+		}
+	}
+	return nil
+}
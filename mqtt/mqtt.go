@@ -0,0 +1,205 @@
+// Package mqtt publishes the state of Daikin units to an MQTT broker, and
+// applies control settings received from it.
+//
+// State is published as JSON to {prefix}/{device}/state, and JSON control
+// settings published to {prefix}/{device}/set are sent to the unit. Fields
+// omitted from a set message are left unchanged.
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/buxtronix/go-daikin"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// DefaultTopicPrefix is the topic prefix used when none is given.
+const DefaultTopicPrefix = "daikin"
+
+// Publisher publishes unit state to an MQTT broker.
+type Publisher struct {
+	// Interval is the interval between state publications.
+	Interval time.Duration
+	// QoS is the MQTT quality of service for published messages.
+	QoS byte
+	// Retain is whether published state messages are retained.
+	Retain bool
+	// DiscoveryPrefix, if set, is the Home Assistant discovery prefix to
+	// publish discovery payloads to on connecting.
+	DiscoveryPrefix string
+	// Logger is the logger for errors in Run. If nil, slog.Default() is
+	// used.
+	Logger *slog.Logger
+
+	client  paho.Client
+	prefix  string
+	devices []*daikin.Daikin
+}
+
+// NewPublisher returns a Publisher for the given broker URL, eg
+// "tcp://localhost:1883". The connection is made by Run.
+func NewPublisher(broker, topicPrefix string, devices []*daikin.Daikin) *Publisher {
+	if topicPrefix == "" {
+		topicPrefix = DefaultTopicPrefix
+	}
+	opts := paho.NewClientOptions().
+		AddBroker(broker).
+		SetClientID(fmt.Sprintf("go-daikin-%d", time.Now().UnixNano())).
+		SetAutoReconnect(true)
+	return &Publisher{
+		Interval: time.Minute,
+		Retain:   true,
+		client:   paho.NewClient(opts),
+		prefix:   topicPrefix,
+		devices:  devices,
+	}
+}
+
+// Client returns the underlying MQTT client.
+func (p *Publisher) Client() paho.Client {
+	return p.client
+}
+
+// DeviceID returns the topic segment identifying the unit: its name if
+// known, else its address, with characters unsafe in topics replaced.
+func DeviceID(d *daikin.Daikin) string {
+	id := d.Name.String()
+	if id == "" {
+		id = d.Address
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return '_'
+	}, id)
+}
+
+// topic returns the topic for the unit with the given suffix.
+func topic(prefix string, d *daikin.Daikin, suffix string) string {
+	return fmt.Sprintf("%s/%s/%s", prefix, DeviceID(d), suffix)
+}
+
+func (p *Publisher) log() *slog.Logger {
+	if p.Logger != nil {
+		return p.Logger
+	}
+	return slog.Default()
+}
+
+// Run connects to the broker, subscribes to the set topics and publishes
+// state every Interval until ctx is done.
+func (p *Publisher) Run(ctx context.Context) error {
+	if t := p.client.Connect(); t.Wait() && t.Error() != nil {
+		return t.Error()
+	}
+	defer p.client.Disconnect(250)
+
+	for _, d := range p.devices {
+		d := d
+		t := p.client.Subscribe(topic(p.prefix, d, "set"), p.QoS, func(_ paho.Client, m paho.Message) {
+			if err := p.handleSet(ctx, d, m.Payload()); err != nil {
+				p.log().Error("set failed", "address", d.Address, "err", err)
+			}
+		})
+		if t.Wait() && t.Error() != nil {
+			return t.Error()
+		}
+	}
+
+	if p.DiscoveryPrefix != "" {
+		if err := p.PublishDiscovery(p.DiscoveryPrefix); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Publish(ctx); err != nil {
+			p.log().Error("publish failed", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Publish fetches and publishes the state of all units once. Units which
+// can't be queried are skipped, and the last error is returned.
+func (p *Publisher) Publish(ctx context.Context) error {
+	var lastErr error
+	for _, d := range p.devices {
+		if err := p.publishDevice(ctx, d); err != nil {
+			lastErr = fmt.Errorf("%s: %v", d.Address, err)
+		}
+	}
+	return lastErr
+}
+
+func (p *Publisher) publishDevice(ctx context.Context, d *daikin.Daikin) error {
+	var state []byte
+	err := d.WithLock(func() error {
+		if err := d.GetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		if err := d.GetSensorInfoContext(ctx); err != nil {
+			return err
+		}
+		var err error
+		state, err = json.Marshal(d)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	t := p.sendState(d, state)
+	t.Wait()
+	return t.Error()
+}
+
+// sendState starts publishing the JSON state of d, returning the token
+// which completes once it is sent.
+func (p *Publisher) sendState(d *daikin.Daikin, state []byte) paho.Token {
+	return p.client.Publish(topic(p.prefix, d, "state"), p.QoS, p.Retain, state)
+}
+
+// handleSet applies the JSON control settings in payload to the unit, and
+// publishes the resulting state. It is called from the subscription
+// callback, so does not wait for the state to be sent: with QoS above 0,
+// waiting for the acknowledgement would block the client's in order
+// delivery of messages, including that acknowledgement.
+func (p *Publisher) handleSet(ctx context.Context, d *daikin.Daikin, payload []byte) error {
+	_, err := d.UpdateControlInfo(ctx, func(c *daikin.ControlInfo) error {
+		return json.Unmarshal(payload, c)
+	})
+	if err != nil {
+		return err
+	}
+	var state []byte
+	err = d.WithLock(func() error {
+		var err error
+		state, err = json.Marshal(d)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	t := p.sendState(d, state)
+	go func() {
+		if t.Wait(); t.Error() != nil {
+			p.log().Error("publish failed", "address", d.Address, "err", t.Error())
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,120 @@
+// Package msgpack encodes the state of Daikin units as MessagePack, a
+// compact binary alternative to JSON for bandwidth limited links.
+//
+// A unit is encoded as an array of its address, name, control settings
+// and sensor values, each of which is an array of fields. Settings are
+// encoded as their protocol values, eg mode 4 for heat, rather than names.
+// Control settings and sensor values are nil if not known.
+package msgpack
+
+import (
+	"github.com/buxtronix/go-daikin"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type device struct {
+	_msgpack struct{} `msgpack:",as_array"`
+	Address  string
+	Name     string
+	Control  *control
+	Sensor   *sensor
+}
+
+type control struct {
+	_msgpack       struct{} `msgpack:",as_array"`
+	Power          int
+	Mode           int
+	Fan            string
+	FanDir         int
+	Temperature    float64
+	Humidity       int32
+	Powerful       bool
+	Eco            bool
+	Streamer       bool
+	ComfortAirflow bool
+	IntelligentEye bool
+}
+
+type sensor struct {
+	_msgpack            struct{} `msgpack:",as_array"`
+	HomeTemperature     float64
+	OutsideTemperature  float64
+	Humidity            int32
+	CompressorFrequency *int
+	InstantPower        *int
+	FilterDirty         bool
+}
+
+// Marshal returns the MessagePack encoding of the unit's address, name,
+// control settings and sensor values.
+func Marshal(d *daikin.Daikin) ([]byte, error) {
+	v := device{
+		Address: d.Address,
+		Name:    d.Name.String(),
+	}
+	if c := d.ControlInfo; c != nil {
+		v.Control = &control{
+			Power:          int(c.Power),
+			Mode:           int(c.Mode),
+			Fan:            string(c.Fan),
+			FanDir:         int(c.FanDir),
+			Temperature:    float64(c.Temperature),
+			Humidity:       int32(c.Humidity),
+			Powerful:       c.Powerful,
+			Eco:            c.Eco,
+			Streamer:       c.Streamer,
+			ComfortAirflow: c.ComfortAirflow,
+			IntelligentEye: c.IntelligentEye,
+		}
+	}
+	if s := d.SensorInfo; s != nil {
+		v.Sensor = &sensor{
+			HomeTemperature:     float64(s.HomeTemperature),
+			OutsideTemperature:  float64(s.OutsideTemperature),
+			Humidity:            int32(s.Humidity),
+			CompressorFrequency: s.CompressorFrequency,
+			InstantPower:        s.InstantPower,
+			FilterDirty:         s.FilterDirty,
+		}
+	}
+	return msgpack.Marshal(&v)
+}
+
+// Unmarshal decodes a unit encoded by Marshal into d, replacing its
+// address, name, control settings and sensor values.
+func Unmarshal(b []byte, d *daikin.Daikin) error {
+	var v device
+	if err := msgpack.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	d.Address = v.Address
+	d.Name = daikin.Name(v.Name)
+	d.ControlInfo = nil
+	if c := v.Control; c != nil {
+		d.ControlInfo = &daikin.ControlInfo{
+			Power:          daikin.Power(c.Power),
+			Mode:           daikin.Mode(c.Mode),
+			Fan:            daikin.Fan(c.Fan),
+			FanDir:         daikin.FanDir(c.FanDir),
+			Temperature:    daikin.Temperature(c.Temperature),
+			Humidity:       daikin.Humidity(c.Humidity),
+			Powerful:       c.Powerful,
+			Eco:            c.Eco,
+			Streamer:       c.Streamer,
+			ComfortAirflow: c.ComfortAirflow,
+			IntelligentEye: c.IntelligentEye,
+		}
+	}
+	d.SensorInfo = nil
+	if s := v.Sensor; s != nil {
+		d.SensorInfo = &daikin.SensorInfo{
+			HomeTemperature:     daikin.Temperature(s.HomeTemperature),
+			OutsideTemperature:  daikin.Temperature(s.OutsideTemperature),
+			Humidity:            daikin.Humidity(s.Humidity),
+			CompressorFrequency: s.CompressorFrequency,
+			InstantPower:        s.InstantPower,
+			FilterDirty:         s.FilterDirty,
+		}
+	}
+	return nil
+}
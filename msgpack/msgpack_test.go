@@ -0,0 +1,101 @@
+package msgpack
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+func testDevice() *daikin.Daikin {
+	freq, power := 42, 850
+	return &daikin.Daikin{
+		Address: "192.168.1.50",
+		Name:    "Lounge",
+		ControlInfo: &daikin.ControlInfo{
+			Power:       daikin.PowerOn,
+			Mode:        daikin.ModeHeat,
+			Fan:         daikin.Fan3,
+			FanDir:      daikin.FanDirVertical,
+			Temperature: 22.5,
+			Humidity:    -1,
+			Eco:         true,
+		},
+		SensorInfo: &daikin.SensorInfo{
+			HomeTemperature:     21.5,
+			OutsideTemperature:  12,
+			Humidity:            45,
+			CompressorFrequency: &freq,
+			InstantPower:        &power,
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, want := range []*daikin.Daikin{
+		testDevice(),
+		{Address: "192.168.1.51"},
+	} {
+		b, err := Marshal(want)
+		if err != nil {
+			t.Fatalf("Marshal(%s) = %v", want.Address, err)
+		}
+		got := &daikin.Daikin{}
+		if err := Unmarshal(b, got); err != nil {
+			t.Fatalf("Unmarshal(%s) = %v", want.Address, err)
+		}
+		if got.Address != want.Address || got.Name != want.Name ||
+			!reflect.DeepEqual(got.ControlInfo, want.ControlInfo) ||
+			!reflect.DeepEqual(got.SensorInfo, want.SensorInfo) {
+			t.Errorf("round trip of %s = %+v, want %+v", want.Address, got, want)
+		}
+	}
+}
+
+func TestSmallerThanJSON(t *testing.T) {
+	d := testDevice()
+	mp, err := Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mp) >= len(js) {
+		t.Errorf("MessagePack is %d bytes, want less than JSON's %d", len(mp), len(js))
+	}
+}
+
+func BenchmarkJSONRoundTrip(b *testing.B) {
+	d := testDevice()
+	var size int
+	for i := 0; i < b.N; i++ {
+		buf, err := json.Marshal(d)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := json.Unmarshal(buf, &daikin.Daikin{}); err != nil {
+			b.Fatal(err)
+		}
+		size = len(buf)
+	}
+	b.ReportMetric(float64(size), "encoded-bytes")
+}
+
+func BenchmarkMessagePackRoundTrip(b *testing.B) {
+	d := testDevice()
+	var size int
+	for i := 0; i < b.N; i++ {
+		buf, err := Marshal(d)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := Unmarshal(buf, &daikin.Daikin{}); err != nil {
+			b.Fatal(err)
+		}
+		size = len(buf)
+	}
+	b.ReportMetric(float64(size), "encoded-bytes")
+}
@@ -1,26 +1,39 @@
 package daikin
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
+	"log/slog"
 	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
 	"time"
-
-	"github.com/golang/glog"
 )
 
 var wantFlags = net.FlagUp | net.FlagBroadcast | net.FlagMulticast
 
 const (
 	udpQueryPayload = "DAIKIN_UDP/common/basic_info"
+	// readBufferSize is the size of each discovery read, which fits any
+	// reply.
+	readBufferSize = 2048
 )
 
 // Option is an option type to pass to NewNetwork.
 type Option func(*DaikinNetwork)
 
-// InterfaceOption configures a specific interface to scan on.
-func InterfaceOption(i string) func(*DaikinNetwork) {
+// InterfaceOption configures specific interfaces to scan on, eg "eth0" and
+// "wlan0". Empty names are ignored. It may be given multiple times.
+func InterfaceOption(names ...string) func(*DaikinNetwork) {
 	return func(d *DaikinNetwork) {
-		d.Interface = i
+		for _, n := range names {
+			if n != "" {
+				d.Interfaces = append(d.Interfaces, n)
+			}
+		}
 	}
 }
 
@@ -29,13 +42,110 @@ func AddressOption(addr string) func(*DaikinNetwork) {
 	return func(d *DaikinNetwork) {
 		if addr != "" {
 			d.Devices = map[string]*Daikin{
-				addr: &Daikin{Address: addr},
+				addr: d.newDevice(addr),
 			}
 			d.PollCount = 0
 		}
 	}
 }
 
+// AddressTokenOption adds a device at a specific address, which requires
+// the given authentication token. It may be given multiple times.
+func AddressTokenOption(addr, token string) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		dev := d.newDevice(addr)
+		dev.Token = token
+		d.Devices[addr] = dev
+		d.PollCount = 0
+	}
+}
+
+// HTTPClientOption configures the HTTP client used to talk to devices.
+func HTTPClientOption(c *http.Client) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.httpClient = c
+	}
+}
+
+// TransportOption wraps the transport of the HTTP client used to talk to
+// devices, eg to instrument requests. It may be given multiple times, with
+// later wrappers outermost.
+func TransportOption(wrap func(http.RoundTripper) http.RoundTripper) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.transports = append(d.transports, wrap)
+	}
+}
+
+// TimeoutOption configures the timeout for requests to devices, and the
+// UDP read deadline during discovery. The zero value disables the timeout.
+func TimeoutOption(t time.Duration) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.Timeout = t
+	}
+}
+
+// RetryOption configures devices to retry transient request failures, up
+// to maxAttempts in total. The delay between attempts starts at
+// initialDelay and doubles after each retry.
+func RetryOption(maxAttempts int, initialDelay time.Duration) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.maxAttempts = maxAttempts
+		d.retryDelay = initialDelay
+	}
+}
+
+// CIDRScanOption configures discovery to send unicast queries to every host
+// in the given subnet, eg "192.168.1.0/24", instead of broadcasting. This
+// suits networks which block broadcast traffic. Subnets larger than a /16
+// are rejected by discovery, to bound the number of hosts queried.
+func CIDRScanOption(cidr string) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.CIDR = cidr
+	}
+}
+
+// PassiveDiscoverOption configures discovery to only listen for
+// announcements from devices, without sending queries. This suits networks
+// which prohibit sending broadcasts.
+func PassiveDiscoverOption() func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.Passive = true
+	}
+}
+
+// UDPBufferSizeOption configures the size in bytes of the socket's UDP
+// receive buffer used for discovery, for busy networks where replies may
+// be dropped.
+func UDPBufferSizeOption(bytes int) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.UDPBufferSize = bytes
+	}
+}
+
+// LoggerOption configures the logger for discovery, and for warnings from
+// the devices. By default slog.Default() is used.
+func LoggerOption(l *slog.Logger) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.logger = l
+	}
+}
+
+// FilterDirtyOption configures a callback, called when GetSensorInfo finds
+// a device reporting its filter needs cleaning or replacement.
+func FilterDirtyOption(callback func(*Daikin)) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.onFilterDirty = callback
+	}
+}
+
+// ConcurrencyOption limits the number of devices queried concurrently by
+// methods operating on all devices. Values below 1 are unlimited.
+func ConcurrencyOption(n int) func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.Concurrency = n
+	}
+}
+
 // NewNetwork returns a new DaikinNetwork, attached to the given interface.
 func NewNetwork(o ...Option) (*DaikinNetwork, error) {
 	dn := &DaikinNetwork{
@@ -46,12 +156,24 @@ func NewNetwork(o ...Option) (*DaikinNetwork, error) {
 	for _, opt := range o {
 		opt(dn)
 	}
+	dn.addHostnames(context.Background())
+	// Options may be ordered after AddressOption.
+	for _, dev := range dn.Devices {
+		dn.configureDevice(dev)
+	}
 	return dn, nil
 }
 
 // A DaikinNetwork represents a local network with Daikin device(s).
 type DaikinNetwork struct {
-	// Interface is the name of the local network interface.
+	// Interfaces are the names of the local network interfaces to scan
+	// on. If empty, all broadcast capable interfaces are scanned. SSDP and
+	// mDNS discovery only use the first.
+	Interfaces []string
+	// Interface is the name of a local network interface to scan on, in
+	// addition to Interfaces.
+	//
+	// Deprecated: Use Interfaces.
 	Interface string
 
 	// PollInterval is the interval to poll for Daikin devices.
@@ -59,10 +181,194 @@ type DaikinNetwork struct {
 	// PollCount is the number of times to poll for Daikin devices.
 	PollCount int
 
-	// Devices are the Daikin devices found on the DaikinNetwork.
+	// CIDR, if set, is the subnet to scan with unicast queries instead of
+	// broadcasting. It must be no larger than a /16.
+	CIDR string
+
+	// Concurrency is the maximum number of devices queried concurrently by
+	// methods operating on all devices. Values below 1 are unlimited.
+	Concurrency int
+
+	// SSDP is whether discovery sends UPnP/SSDP searches instead of
+	// broadcasting.
+	SSDP bool
+
+	// Passive is whether discovery only listens for announcements, without
+	// sending queries.
+	Passive bool
+
+	// UDPBufferSize, if set, is the size of the socket's UDP receive
+	// buffer during discovery.
+	UDPBufferSize int
+
+	// Timeout is the timeout for requests to devices. If set, it is also
+	// the UDP read deadline during discovery instead of PollInterval.
+	Timeout time.Duration
+
+	// Devices are the Daikin devices found on the DaikinNetwork, keyed by
+	// address. Use DeviceList instead while discovery may be running.
 	Devices map[string]*Daikin
+	// mu guards Devices and scenes.
+	mu sync.RWMutex
 
 	broadcasts []net.IP
+	logger     *slog.Logger
+	// hostnames are the devices added by AddressNameOption.
+	hostnames []string
+
+	onFilterDirty func(*Daikin)
+
+	// scenes are the scenes registered with RegisterScene.
+	scenes map[Scene]ControlInfo
+
+	// rediscoverMu serialises discovery by devices configured with
+	// WithAutoRediscover.
+	rediscoverMu sync.Mutex
+
+	httpClient  *http.Client
+	transports  []func(http.RoundTripper) http.RoundTripper
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+// log returns the network's logger.
+func (d *DaikinNetwork) log() *slog.Logger {
+	if d.logger != nil {
+		return d.logger
+	}
+	return slog.Default()
+}
+
+// Logger returns the logger configured by LoggerOption, or slog.Default()
+// if none, for use by options from other packages.
+func (d *DaikinNetwork) Logger() *slog.Logger {
+	return d.log()
+}
+
+// newDevice returns a new Daikin at the given address, configured with the
+// network's settings.
+func (d *DaikinNetwork) newDevice(addr string) *Daikin {
+	dev := &Daikin{Address: addr}
+	d.configureDevice(dev)
+	return dev
+}
+
+// configureDevice applies the network's settings to the device.
+func (d *DaikinNetwork) configureDevice(dev *Daikin) {
+	// Start from the network's client, so the device's is not wrapped twice.
+	dev.HTTPClient = d.httpClient
+	dev.MaxAttempts = d.maxAttempts
+	dev.RetryDelay = d.retryDelay
+	dev.onFilterDirty = d.onFilterDirty
+	dev.logger = d.logger
+	if d.Timeout > 0 || len(d.transports) > 0 {
+		// Copy the client so a caller supplied one is not modified.
+		c := *dev.client()
+		if d.Timeout > 0 {
+			c.Timeout = d.Timeout
+		}
+		for _, wrap := range d.transports {
+			t := c.Transport
+			if t == nil {
+				t = http.DefaultTransport
+			}
+			c.Transport = wrap(t)
+		}
+		dev.HTTPClient = &c
+	}
+}
+
+// AddDevice adds a device at the given address, configured with the
+// network's settings and then the given options. It returns an error if a
+// device is already registered at the address.
+func (d *DaikinNetwork) AddDevice(address string, opts ...DeviceOption) (*Daikin, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.Devices[address]; ok {
+		return nil, fmt.Errorf("device already registered: %s", address)
+	}
+	dev := d.newDevice(address)
+	dev.Apply(opts...)
+	d.Devices[address] = dev
+	return dev, nil
+}
+
+// RemoveDevice removes the device at the given address, eg after it has
+// been decommissioned. It returns whether the device existed. Discovery
+// adds it again if it is still on the network.
+func (d *DaikinNetwork) RemoveDevice(address string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.Devices[address]
+	delete(d.Devices, address)
+	return ok
+}
+
+// DeviceList returns a snapshot of the devices, which is safe to use while
+// discovery may be adding devices.
+func (d *DaikinNetwork) DeviceList() []*Daikin {
+	return d.devices()
+}
+
+// devices returns the devices as a slice.
+func (d *DaikinNetwork) devices() []*Daikin {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	devs := make([]*Daikin, 0, len(d.Devices))
+	for _, dev := range d.Devices {
+		devs = append(devs, dev)
+	}
+	return devs
+}
+
+// GetAllControlInfo concurrently gets the control info of all devices. It
+// returns the errors of any devices which failed, keyed by address.
+func (d *DaikinNetwork) GetAllControlInfo(ctx context.Context) map[string]error {
+	return forEachDevice(d.devices(), d.Concurrency, func(dev *Daikin) error {
+		return dev.GetControlInfoContext(ctx)
+	})
+}
+
+// averageTemperature averages the given sensor temperature over all devices
+// with sensor info.
+func (d *DaikinNetwork) averageTemperature(temp func(*SensorInfo) Temperature) (Temperature, error) {
+	var sum Temperature
+	n := 0
+	for _, dev := range d.devices() {
+		if dev.SensorInfo == nil {
+			continue
+		}
+		sum += temp(dev.SensorInfo)
+		n++
+	}
+	if n == 0 {
+		return 0, ErrNoSensorInfo
+	}
+	return sum / Temperature(n), nil
+}
+
+// AverageIndoorTemperature returns the average home temperature of all
+// devices, from their last fetched sensor info. It returns ErrNoSensorInfo
+// if no device has sensor info.
+func (d *DaikinNetwork) AverageIndoorTemperature() (Temperature, error) {
+	return d.averageTemperature(func(s *SensorInfo) Temperature { return s.HomeTemperature })
+}
+
+// AverageOutdoorTemperature returns the average outside temperature of all
+// devices, from their last fetched sensor info. It returns ErrNoSensorInfo
+// if no device has sensor info.
+func (d *DaikinNetwork) AverageOutdoorTemperature() (Temperature, error) {
+	return d.averageTemperature(func(s *SensorInfo) Temperature { return s.OutsideTemperature })
+}
+
+// interfaces returns the names of the interfaces to scan on, or none to
+// scan on all.
+func (d *DaikinNetwork) interfaces() []string {
+	names := d.Interfaces
+	if d.Interface != "" && !slices.Contains(names, d.Interface) {
+		names = append([]string{d.Interface}, names...)
+	}
+	return names
 }
 
 // getBroadcastAddresses fetches and populates the interface broadcast addresses.
@@ -72,26 +378,27 @@ func (d *DaikinNetwork) getBroadcastAddresses() error {
 	if err != nil {
 		return err
 	}
+	names := d.interfaces()
 	for _, i := range interfaces {
-		if i.Flags != wantFlags || d.Interface != "" && i.Name != d.Interface {
+		if i.Flags != wantFlags || len(names) > 0 && !slices.Contains(names, i.Name) {
 			continue
 		}
 		// Fetch interface addresses.
 		adr, err := i.Addrs()
 		if err != nil {
-			glog.Warningf("%s: Can't get addresses, skipping.", i.Name)
+			d.log().Warn("can't get addresses, skipping", "interface", i.Name, "err", err)
 			continue
 		}
 		for _, a := range adr {
 			// Parse the address.
 			ip, network, err := net.ParseCIDR(a.String())
 			if err != nil {
-				glog.Infof("%s: Can't parse %s, skipping.", i.Name, a.String())
+				d.log().Debug("can't parse address, skipping", "interface", i.Name, "address", a.String())
 				continue
 			}
 			// Test if it is V4 (no daikin does ipv6).
 			if four := ip.To4(); four == nil {
-				glog.Infof("%s: %s: Skipping non-v4 address", i.Name, ip)
+				d.log().Debug("skipping non-v4 address", "interface", i.Name, "address", ip)
 				continue
 			}
 			// Calculate and add the broadcast address.
@@ -102,22 +409,179 @@ func (d *DaikinNetwork) getBroadcastAddresses() error {
 			d.broadcasts = append(d.broadcasts, bCast)
 		}
 	}
-	if len(d.broadcasts) == 0 && d.Interface != "" {
-		return fmt.Errorf("no interface or no addresses: %s", d.Interface)
+	if len(d.broadcasts) == 0 && len(names) > 0 {
+		return fmt.Errorf("no interface or no addresses: %s", strings.Join(names, ","))
+	}
+	d.log().Debug("broadcast addresses", "addresses", d.broadcasts)
+	return nil
+}
+
+// minCIDRPrefix is the shortest prefix length cidrHosts accepts, limiting
+// a scan to 65536 addresses.
+const minCIDRPrefix = 16
+
+// cidrHosts returns the host addresses in the given IPv4 subnet, excluding
+// the network and broadcast addresses. Subnets with a prefix shorter than
+// minCIDRPrefix are rejected.
+func cidrHosts(cidr string) ([]net.IP, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("not an IPv4 subnet: %s", cidr)
+	}
+	ones, bits := network.Mask.Size()
+	if ones < minCIDRPrefix {
+		return nil, fmt.Errorf("subnet %s is larger than /%d", cidr, minCIDRPrefix)
+	}
+	start := binary.BigEndian.Uint32(network.IP.To4())
+	end := start | (1<<uint(bits-ones) - 1)
+	if bits-ones >= 2 {
+		start, end = start+1, end-1
+	}
+	hosts := []net.IP{}
+	for i := uint64(start); i <= uint64(end); i++ {
+		h := make(net.IP, 4)
+		binary.BigEndian.PutUint32(h, uint32(i))
+		hosts = append(hosts, h)
+	}
+	return hosts, nil
+}
+
+// normalizeMAC returns the MAC address in the form reported by units, eg
+// "A0B1C2D3E4F5".
+func normalizeMAC(mac string) string {
+	return strings.ToUpper(strings.NewReplacer(":", "", "-", "").Replace(mac))
+}
+
+// DeviceByAddress returns the device at the given address.
+func (d *DaikinNetwork) DeviceByAddress(address string) (*Daikin, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	dev, ok := d.Devices[address]
+	return dev, ok
+}
+
+// DeviceByMAC returns the device with the given MAC address, from its
+// basic info. The address may be given with or without separators.
+func (d *DaikinNetwork) DeviceByMAC(mac string) (*Daikin, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.deviceByMAC(mac)
+}
+
+// deviceByMAC is DeviceByMAC, with mu held by the caller.
+func (d *DaikinNetwork) deviceByMAC(mac string) (*Daikin, bool) {
+	mac = normalizeMAC(mac)
+	for _, dev := range d.Devices {
+		if dev.BasicInfo != nil && normalizeMAC(dev.BasicInfo.MAC) == mac {
+			return dev, true
+		}
+	}
+	return nil, false
+}
+
+// addFound adds a device found by discovery at addr, if not already known.
+func (d *DaikinNetwork) addFound(addr string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.Devices[addr]; !ok {
+		d.Devices[addr] = d.newDevice(addr)
+	}
+}
+
+// addDiscovered adds the device at ip which sent the given discovery reply,
+// if not already known. The reply contains the basic info of the unit, so
+// a known unit which has changed address, eg after a DHCP lease renewal,
+// is moved rather than duplicated.
+func (d *DaikinNetwork) addDiscovered(ip, reply string) {
+	b := &BasicInfo{}
+	vals, err := parseValues(reply)
+	if err == nil {
+		err = b.populate(vals)
+	}
+	if err != nil || b.MAC == "" {
+		d.log().Debug("can't parse discovery reply", "from", ip, "err", err)
+		d.addFound(ip)
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if dev, ok := d.deviceByMAC(b.MAC); ok {
+		if old := dev.address(); old != ip {
+			d.log().Info("device changed address", "mac", b.MAC, "from", old, "to", ip)
+			delete(d.Devices, old)
+			dev.setAddress(ip)
+			d.Devices[ip] = dev
+		}
+	}
+	dev, ok := d.Devices[ip]
+	if !ok {
+		dev = d.newDevice(ip)
+		d.Devices[ip] = dev
+	}
+	dev.BasicInfo = b
+}
+
+// setReadBuffer sets the receive buffer size of the discovery socket, if
+// configured.
+func (d *DaikinNetwork) setReadBuffer(conn *net.UDPConn) error {
+	if d.UDPBufferSize > 0 {
+		return conn.SetReadBuffer(d.UDPBufferSize)
 	}
-	glog.Infof("Broadcast addresses: %v", d.broadcasts)
 	return nil
 }
 
+// readTimeout returns the UDP read deadline for discovery.
+func (d *DaikinNetwork) readTimeout() time.Duration {
+	if d.Timeout > 0 {
+		return d.Timeout
+	}
+	return d.PollInterval
+}
+
 // Discover runs a UDP polling cycle for Daikin devices.
 // Sends UDP packet to broadcast address, dst port 30050 with payload:
 // DAIKIN_UDP/common/basic_info
 func (d *DaikinNetwork) Discover() error {
+	return d.DiscoverWithContext(context.Background())
+}
+
+// DiscoverWithContext runs a UDP polling cycle for Daikin devices, as for
+// Discover. If ctx is done before the cycle completes, polling stops and
+// ctx.Err() is returned.
+func (d *DaikinNetwork) DiscoverWithContext(ctx context.Context) error {
 	if d.PollCount < 1 {
 		return nil
 	}
-	if err := d.getBroadcastAddresses(); err != nil {
-		return err
+	return d.discover(ctx, d.PollCount)
+}
+
+// discover runs a polling cycle of the given number of polls.
+func (d *DaikinNetwork) discover(ctx context.Context, polls int) error {
+	if d.SSDP {
+		return d.discoverSSDP(ctx, polls)
+	}
+	// Each poller sends to a group of addresses: a single broadcast
+	// address, or all hosts in the configured CIDR.
+	var groups [][]net.IP
+	if d.Passive {
+		// A single poller listens, with no addresses to send to.
+		groups = append(groups, nil)
+	} else if d.CIDR != "" {
+		hosts, err := cidrHosts(d.CIDR)
+		if err != nil {
+			return err
+		}
+		groups = append(groups, hosts)
+	} else {
+		if err := d.getBroadcastAddresses(); err != nil {
+			return err
+		}
+		for _, b := range d.broadcasts {
+			groups = append(groups, []net.IP{b})
+		}
 	}
 	// Open a local listener.
 	lAddr := net.UDPAddr{Port: 30000}
@@ -126,51 +590,73 @@ func (d *DaikinNetwork) Discover() error {
 		return err
 	}
 	defer conn.Close()
+	if err := d.setReadBuffer(conn); err != nil {
+		return err
+	}
 
-	// A poller sends to broadcast and awaits replies.
-	poller := func(bCast string, done chan bool) {
-		glog.Infof("Start polling to: %s", bCast)
-		for i := 0; i < d.PollCount; i++ {
-			// Send broadcast packet.
-			rAddr := &net.UDPAddr{IP: net.ParseIP(bCast), Port: 30050}
-			if _, err := conn.WriteToUDP([]byte(udpQueryPayload), rAddr); err != nil {
-				glog.Errorf("write: err: %v\n", err)
-				continue
+	// Unblock any pending read when the context is done.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetReadDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+
+	// A poller sends to its addresses and awaits replies.
+	poller := func(addrs []net.IP, done chan bool) {
+		// Each poller reads concurrently, so has its own buffer.
+		rBuf := make([]byte, readBufferSize)
+		switch len(addrs) {
+		case 0:
+			d.log().Debug("start listening")
+		case 1:
+			d.log().Debug("start polling", "address", addrs[0])
+		default:
+			d.log().Debug("start polling", "count", len(addrs))
+		}
+		for i := 0; i < polls && ctx.Err() == nil; i++ {
+			// Send query packets.
+			for _, a := range addrs {
+				rAddr := &net.UDPAddr{IP: a, Port: 30050}
+				if _, err := conn.WriteToUDP([]byte(udpQueryPayload), rAddr); err != nil {
+					d.log().Error("write failed", "address", a, "err", err)
+				}
 			}
 			// Read until the deadline.
 			for {
-				rBuf := make([]byte, 2048)
-				conn.SetReadDeadline(time.Now().Add(d.PollInterval))
+				conn.SetReadDeadline(time.Now().Add(d.readTimeout()))
+				if ctx.Err() != nil {
+					break
+				}
 				n, rAddr, err := conn.ReadFromUDP(rBuf)
 				if err != nil {
 					if err, ok := err.(net.Error); ok && err.Timeout() {
 						break
 					}
-					glog.Errorf("read err: %v\n", err)
+					d.log().Error("read failed", "err", err)
 					continue
 				}
-				glog.Infof("%d bytes from %v: %v\n", n, rAddr, string(rBuf))
+				d.log().Debug("discovery reply", "from", rAddr, "payload", string(rBuf[:n]))
 
-				ip := rAddr.IP.String()
-				if _, ok := d.Devices[ip]; !ok {
-					dev := &Daikin{Address: ip}
-					d.Devices[ip] = dev
-				}
+				d.addDiscovered(rAddr.IP.String(), string(rBuf[:n]))
 			}
 		}
 		close(done)
 	}
 
-	// Start pollers per broadcast address, wait for them to complete.
+	// Start pollers per address group, wait for them to complete.
 	pollers := []chan bool{}
-	for _, b := range d.broadcasts {
+	for _, g := range groups {
 		ch := make(chan bool)
-		go poller(b.String(), ch)
+		go poller(g, ch)
 		pollers = append(pollers, ch)
 	}
 	for _, ch := range pollers {
 		_, _ = <-ch
 	}
 
-	return nil
+	return ctx.Err()
 }
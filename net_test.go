@@ -0,0 +1,65 @@
+package daikin_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/buxtronix/go-daikin"
+	"github.com/buxtronix/go-daikin/daikintest"
+)
+
+// TestRediscoverWhilePolling moves a device between two addresses of the
+// same unit by discovery, while it is being polled. Run with -race.
+func TestRediscoverWhilePolling(t *testing.T) {
+	m := daikintest.NewMockDevice(t)
+	addrs := []string{m.Address(), strings.Replace(m.Address(), "127.0.0.1", "localhost", 1)}
+	n, err := daikin.NewNetwork(
+		daikin.AddressTokenOption(addrs[0], ""),
+		daikin.LoggerOption(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	)
+	if err != nil {
+		t.Fatalf("NewNetwork() = %v", err)
+	}
+	dev, _ := n.DeviceByAddress(addrs[0])
+	if err := dev.GetBasicInfo(); err != nil {
+		t.Fatalf("GetBasicInfo() = %v", err)
+	}
+	const reply = "ret=OK,type=aircon,reg=au,name=%4d%6f%63%6b,mac=A408EAD3C3B6"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	polled := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			if err := dev.GetControlInfoContext(ctx); err != nil && ctx.Err() == nil {
+				t.Errorf("GetControlInfo() = %v", err)
+			}
+			select {
+			case polled <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	// Moves the device to addrs[1] and back, ending on addrs[1].
+	for i := 1; i <= 51; i++ {
+		<-polled
+		n.AddDiscovered(addrs[i%2], reply)
+	}
+	cancel()
+	wg.Wait()
+
+	devs := n.DeviceList()
+	if len(devs) != 1 || devs[0] != dev {
+		t.Fatalf("DeviceList() = %v, want only the original device", devs)
+	}
+	if got, ok := n.DeviceByAddress(addrs[1]); !ok || got != dev {
+		t.Errorf("DeviceByAddress(%q) = %v, %t, want the moved device", addrs[1], got, ok)
+	}
+}
@@ -0,0 +1,67 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+)
+
+// NotifyInfo represents the maintenance notifications of the unit.
+type NotifyInfo struct {
+	// DirtSignal is whether the unit has detected a dirty filter.
+	DirtSignal bool
+	// FilterSign is whether the filter cleaning sign is shown.
+	FilterSign bool
+	// InsideClean is whether the unit requests an internal clean.
+	InsideClean bool
+}
+
+func (n *NotifyInfo) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "dirt_sig":
+			err = decodeBool(&n.DirtSignal, k, v)
+		case "filter_sign":
+			err = decodeBool(&n.FilterSign, k, v)
+		case "inside_clean":
+			err = decodeBool(&n.InsideClean, k, v)
+		case "ret":
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+		}
+		if err != nil {
+			return &ParseError{Key: k, Value: v, Err: err}
+		}
+	}
+	return nil
+}
+
+// FilterDirty returns whether the filter needs cleaning or replacement.
+func (n *NotifyInfo) FilterDirty() bool {
+	return n.DirtSignal || n.FilterSign
+}
+
+func (n *NotifyInfo) String() string {
+	return fmt.Sprintf("dirt_sig: %t\nfilter_sign: %t\ninside_clean: %t\n", n.DirtSignal, n.FilterSign, n.InsideClean)
+}
+
+// GetNotify gets the maintenance notifications for the unit. A warning is
+// logged if the filter needs attention.
+func (d *Daikin) GetNotify() error {
+	return d.GetNotifyContext(context.Background())
+}
+
+// GetNotifyContext gets the maintenance notifications for the unit, using
+// the given context.
+func (d *Daikin) GetNotifyContext(ctx context.Context) error {
+	n := &NotifyInfo{}
+	if err := d.fetch(ctx, uriGetNotify, n); err != nil {
+		return err
+	}
+	d.NotifyInfo = n
+	if n.FilterDirty() {
+		d.log().Warn("filter needs cleaning or replacement", "address", d.Address)
+	}
+	return nil
+}
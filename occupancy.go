@@ -0,0 +1,72 @@
+package daikin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// OccupancyController configures units from presence detection, eg by a
+// motion sensor on a home automation hub. Units are switched to the vacant
+// settings only after no presence has been detected for VacancyDelay, so
+// that brief gaps in detection do not turn them off.
+type OccupancyController struct {
+	// OnOccupied is the settings used when presence is detected.
+	OnOccupied ControlInfo
+	// OnVacant is the settings used when the room is vacant.
+	OnVacant ControlInfo
+	// VacancyDelay is how long the room must be vacant before the vacant
+	// settings are applied. If zero, they are applied immediately.
+	VacancyDelay time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*time.Timer
+}
+
+// SetOccupied cancels any pending switch to vacant, and configures the unit
+// with the occupied settings.
+func (o *OccupancyController) SetOccupied(ctx context.Context, d *Daikin) error {
+	o.mu.Lock()
+	if t, ok := o.pending[d.Address]; ok {
+		t.Stop()
+		delete(o.pending, d.Address)
+	}
+	o.mu.Unlock()
+	_, err := d.SetControlInfoIfChanged(ctx, o.OnOccupied)
+	return err
+}
+
+// SetVacant configures the unit with the vacant settings once VacancyDelay
+// has passed without a call to SetOccupied. If VacancyDelay is non-zero it
+// returns immediately, and any error configuring the unit is logged.
+func (o *OccupancyController) SetVacant(ctx context.Context, d *Daikin) error {
+	if o.VacancyDelay <= 0 {
+		_, err := d.SetControlInfoIfChanged(ctx, o.OnVacant)
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, ok := o.pending[d.Address]; ok {
+		return nil
+	}
+	if o.pending == nil {
+		o.pending = map[string]*time.Timer{}
+	}
+	ctx = context.WithoutCancel(ctx)
+	var t *time.Timer
+	t = time.AfterFunc(o.VacancyDelay, func() {
+		o.mu.Lock()
+		if o.pending[d.Address] != t {
+			// Cancelled by SetOccupied.
+			o.mu.Unlock()
+			return
+		}
+		delete(o.pending, d.Address)
+		o.mu.Unlock()
+		if _, err := d.SetControlInfoIfChanged(ctx, o.OnVacant); err != nil {
+			d.log().Error("failed to set vacant settings", "address", d.Address, "err", err)
+		}
+	})
+	o.pending[d.Address] = t
+	return nil
+}
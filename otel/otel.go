@@ -0,0 +1,77 @@
+// Package otel traces requests to Daikin units with OpenTelemetry.
+//
+// Each HTTP request to a unit is recorded as a span named after the unit
+// endpoint, eg "daikin.GetControlInfo" for /aircon/get_control_info. Each
+// retried attempt is recorded as a separate span.
+package otel
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/buxtronix/go-daikin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attribute keys.
+const (
+	AddressKey    = attribute.Key("daikin.address")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+	RetryCountKey = attribute.Key("daikin.retry_count")
+)
+
+// InstrumentedClient returns an option to pass to daikin.NewNetwork, which
+// traces requests to devices with the given tracer.
+func InstrumentedClient(tracer trace.Tracer) daikin.Option {
+	return daikin.TransportOption(func(rt http.RoundTripper) http.RoundTripper {
+		return &transport{tracer: tracer, base: rt}
+	})
+}
+
+// transport is an http.RoundTripper which traces requests.
+type transport struct {
+	tracer trace.Tracer
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retries := 0
+	if a := daikin.AttemptFromContext(req.Context()); a > 1 {
+		retries = a - 1
+	}
+	ctx, span := t.tracer.Start(req.Context(), SpanName(req.URL.Path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AddressKey.String(req.URL.Host),
+			RetryCountKey.Int(retries),
+		))
+	defer span.End()
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(StatusCodeKey.Int(resp.StatusCode))
+	if resp.StatusCode != http.StatusOK {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+// SpanName returns the span name for a unit endpoint, eg
+// "daikin.GetControlInfo" for "/aircon/get_control_info".
+func SpanName(uri string) string {
+	var b strings.Builder
+	b.WriteString("daikin.")
+	for _, w := range strings.Split(path.Base(uri), "_") {
+		if w != "" {
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	return b.String()
+}
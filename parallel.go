@@ -0,0 +1,33 @@
+package daikin
+
+import "sync"
+
+// forEachDevice calls fn concurrently for each device, with at most limit
+// calls in flight (unlimited if limit < 1). It returns the errors from
+// failed calls keyed by device address, or an empty map if all succeeded.
+func forEachDevice(devices []*Daikin, limit int, fn func(*Daikin) error) map[string]error {
+	if limit < 1 {
+		limit = len(devices)
+	}
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = map[string]error{}
+		sem  = make(chan struct{}, limit)
+	)
+	for _, dev := range devices {
+		wg.Add(1)
+		go func(dev *Daikin) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if err := fn(dev); err != nil {
+				mu.Lock()
+				errs[dev.Address] = err
+				mu.Unlock()
+			}
+		}(dev)
+	}
+	wg.Wait()
+	return errs
+}
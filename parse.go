@@ -0,0 +1,70 @@
+package daikin
+
+import "strings"
+
+// ParseMode parses a mode from either its name (eg "Heat", case
+// insensitive) or its protocol value (eg "4"). "Auto" parses as ModeAuto.
+func ParseMode(s string) (Mode, error) {
+	found := false
+	var m Mode
+	for k, v := range modeMap {
+		// Several modes share a name, pick the lowest.
+		if strings.EqualFold(v, s) && (!found || k < m) {
+			m = k
+			found = true
+		}
+	}
+	if found {
+		return m, nil
+	}
+	if err := m.decode(s); err != nil {
+		return 0, &ErrUnknownValue{Type: "mode", Value: s}
+	}
+	return m, nil
+}
+
+// ParseFan parses a fan speed from either its name (eg "Silent", case
+// insensitive) or its protocol value (eg "B"). Names take precedence, so
+// "3" parses as Fan3 rather than the protocol value for Fan1.
+func ParseFan(s string) (Fan, error) {
+	for k, v := range fanMap {
+		if strings.EqualFold(v, s) {
+			return k, nil
+		}
+	}
+	var f Fan
+	if err := f.decode(strings.ToUpper(s)); err != nil {
+		return "", &ErrUnknownValue{Type: "fan", Value: s}
+	}
+	return f, nil
+}
+
+// ParseFanDir parses a louvre setting from either its name (eg "Vertical",
+// case insensitive) or its protocol value (eg "1").
+func ParseFanDir(s string) (FanDir, error) {
+	for k, v := range fanDirMap {
+		if strings.EqualFold(v, s) {
+			return k, nil
+		}
+	}
+	var f FanDir
+	if err := f.decode(s); err != nil {
+		return 0, &ErrUnknownValue{Type: "fan_dir", Value: s}
+	}
+	return f, nil
+}
+
+// ParsePower parses a power status from either its name (eg "On", case
+// insensitive) or its protocol value (eg "1").
+func ParsePower(s string) (Power, error) {
+	for k, v := range powerMap {
+		if strings.EqualFold(v, s) {
+			return k, nil
+		}
+	}
+	var p Power
+	if err := p.decode(s); err != nil {
+		return 0, &ErrUnknownValue{Type: "power", Value: s}
+	}
+	return p, nil
+}
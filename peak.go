@@ -0,0 +1,64 @@
+package daikin
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// PeakPeriod is a weekly period of peak rate electricity pricing.
+type PeakPeriod struct {
+	// Weekdays are the days the period starts on.
+	Weekdays []time.Weekday
+	// Start is the time of day the period starts, as the offset from
+	// midnight.
+	Start time.Duration
+	// End is the time of day the period ends, as the offset from
+	// midnight. If not after Start, the period ends the following day.
+	End time.Duration
+}
+
+// Contains returns whether t is within the period, in t's location.
+func (p PeakPeriod) Contains(t time.Time) bool {
+	y, m, d := t.Date()
+	offset := t.Sub(time.Date(y, m, d, 0, 0, 0, 0, t.Location()))
+	today := slices.Contains(p.Weekdays, t.Weekday())
+	if p.Start < p.End {
+		return today && offset >= p.Start && offset < p.End
+	}
+	yesterday := slices.Contains(p.Weekdays, (t.Weekday()+6)%7)
+	return (today && offset >= p.Start) || (yesterday && offset < p.End)
+}
+
+// PeakAvoidanceScheduler configures units with different settings during
+// peak rate periods, to reduce electricity costs.
+type PeakAvoidanceScheduler struct {
+	// Peak is the settings used during peak periods.
+	Peak ControlInfo
+	// OffPeak is the settings used outside peak periods.
+	OffPeak ControlInfo
+	// Periods are the peak periods.
+	Periods []PeakPeriod
+}
+
+// IsPeak returns whether t is within any of the peak periods.
+func (s *PeakAvoidanceScheduler) IsPeak(t time.Time) bool {
+	for _, p := range s.Periods {
+		if p.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tick configures the unit with the peak or off peak settings for now, if
+// they differ from the unit's current settings. It should be called
+// periodically, eg every minute.
+func (s *PeakAvoidanceScheduler) Tick(ctx context.Context, d *Daikin, now time.Time) error {
+	desired := s.OffPeak
+	if s.IsPeak(now) {
+		desired = s.Peak
+	}
+	_, err := d.SetControlInfoIfChanged(ctx, desired)
+	return err
+}
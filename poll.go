@@ -0,0 +1,81 @@
+package daikin
+
+import (
+	"context"
+	"time"
+)
+
+// SensorSnapshot is the sensor and control state of a unit at a point in
+// time.
+type SensorSnapshot struct {
+	SensorInfo  `json:"sensor_info"`
+	ControlInfo `json:"control_info"`
+	// Address is the address of the unit.
+	Address string `json:"address"`
+	// Timestamp is the time the state was fetched.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// FetchSnapshot fetches the current sensor and control state of the unit.
+// The unit is locked throughout, as for WithLock.
+func (d *Daikin) FetchSnapshot(ctx context.Context) (SensorSnapshot, error) {
+	var snap SensorSnapshot
+	err := d.WithLock(func() error {
+		if err := d.GetSensorInfoContext(ctx); err != nil {
+			return err
+		}
+		if err := d.GetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		snap = SensorSnapshot{
+			SensorInfo:  *d.SensorInfo,
+			ControlInfo: *d.ControlInfo,
+			Address:     d.address(),
+			Timestamp:   time.Now(),
+		}
+		return nil
+	})
+	return snap, err
+}
+
+// Poll fetches the sensor and control state of the unit immediately and
+// then every interval, sending each snapshot to the returned channel. It
+// returns immediately, and polling stops when ctx is done, which closes
+// both channels. Errors are sent to the error channel, and dropped if a
+// previous error has not been received. The unit's SensorInfo and
+// ControlInfo are updated by each poll. If interval is not positive, one
+// minute is used.
+func (d *Daikin) Poll(ctx context.Context, interval time.Duration) (<-chan SensorSnapshot, <-chan error) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	snaps := make(chan SensorSnapshot)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(snaps)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			snap, err := d.FetchSnapshot(ctx)
+			if err != nil {
+				select {
+				case errs <- err:
+				default:
+				}
+			} else {
+				select {
+				case snaps <- snap:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return snaps, errs
+}
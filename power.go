@@ -0,0 +1,157 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// noData is the placeholder value reported for periods with no data.
+const noData = "---"
+
+// decodeEnergy decodes a "/" separated list of energy values, scaling each
+// value by scale. Placeholder values decode as NaN.
+func decodeEnergy(s string, scale float64) ([]float64, error) {
+	parts := strings.Split(s, "/")
+	vals := make([]float64, len(parts))
+	for i, p := range parts {
+		if p == noData || p == "" {
+			vals[i] = math.NaN()
+			continue
+		}
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = v * scale
+	}
+	return vals, nil
+}
+
+// sumEnergy sums the values, ignoring any NaN (no data) entries.
+func sumEnergy(vals []float64) float64 {
+	var total float64
+	for _, v := range vals {
+		if !math.IsNaN(v) {
+			total += v
+		}
+	}
+	return total
+}
+
+// WeekPowerInfo represents the daily energy consumption over the past week.
+type WeekPowerInfo struct {
+	// TodayRuntime is the number of minutes the unit has run today.
+	TodayRuntime int
+	// Days is the energy consumption in kWh for each day, where index 0
+	// is today. Days without data are NaN.
+	Days []float64
+}
+
+func (w *WeekPowerInfo) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "today_runtime":
+			err = decodeInt(&w.TodayRuntime, v)
+		case "datas":
+			// Reported in Wh, oldest day first.
+			var days []float64
+			days, err = decodeEnergy(v, 0.001)
+			for i, j := 0, len(days)-1; i < j; i, j = i+1, j-1 {
+				days[i], days[j] = days[j], days[i]
+			}
+			w.Days = days
+		case "ret":
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+		}
+		if err != nil {
+			return &ParseError{Key: k, Value: v, Err: err}
+		}
+	}
+	return nil
+}
+
+// TotalWeek returns the total energy consumption in kWh over the week.
+func (w *WeekPowerInfo) TotalWeek() float64 {
+	return sumEnergy(w.Days)
+}
+
+func (w *WeekPowerInfo) String() string {
+	return fmt.Sprintf("today_runtime: %d\nweek_kwh: %.1f\n", w.TodayRuntime, w.TotalWeek())
+}
+
+// GetWeekPower gets the daily energy consumption for the past week.
+func (d *Daikin) GetWeekPower() error {
+	return d.GetWeekPowerContext(context.Background())
+}
+
+// GetWeekPowerContext gets the daily energy consumption for the past week,
+// using the given context.
+func (d *Daikin) GetWeekPowerContext(ctx context.Context) error {
+	w := &WeekPowerInfo{}
+	if err := d.fetch(ctx, uriGetWeekPower, w); err != nil {
+		return err
+	}
+	d.WeekPowerInfo = w
+	return nil
+}
+
+// YearPowerInfo represents the monthly energy consumption for the year.
+type YearPowerInfo struct {
+	// Months is the energy consumption in kWh for each month of this
+	// year, where index 0 is January. Months without data are NaN.
+	Months []float64
+	// PreviousYear is the energy consumption in kWh for each month of
+	// the previous year, where index 0 is January.
+	PreviousYear []float64
+}
+
+func (y *YearPowerInfo) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "this_year":
+			y.Months, err = decodeEnergy(v, 1)
+		case "previous_year":
+			y.PreviousYear, err = decodeEnergy(v, 1)
+		case "ret":
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+		}
+		if err != nil {
+			return &ParseError{Key: k, Value: v, Err: err}
+		}
+	}
+	return nil
+}
+
+// TotalYear returns the total energy consumption in kWh for this year.
+func (y *YearPowerInfo) TotalYear() float64 {
+	return sumEnergy(y.Months)
+}
+
+func (y *YearPowerInfo) String() string {
+	return fmt.Sprintf("year_kwh: %.1f\nprevious_year_kwh: %.1f\n", y.TotalYear(), sumEnergy(y.PreviousYear))
+}
+
+// GetYearPower gets the monthly energy consumption for the year.
+func (d *Daikin) GetYearPower() error {
+	return d.GetYearPowerContext(context.Background())
+}
+
+// GetYearPowerContext gets the monthly energy consumption for the year,
+// using the given context.
+func (d *Daikin) GetYearPowerContext(ctx context.Context) error {
+	y := &YearPowerInfo{}
+	if err := d.fetch(ctx, uriGetYearPower, y); err != nil {
+		return err
+	}
+	d.YearPowerInfo = y
+	return nil
+}
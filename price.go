@@ -0,0 +1,64 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// PriceInfo represents the electricity price configured on the unit, for
+// estimating running costs.
+type PriceInfo struct {
+	// Price is the rate per kWh.
+	Price float64
+	// CurrencyCode is the ISO 4217 currency of Price, eg "AUD". The unit
+	// does not report it, so it is left for the caller to set.
+	CurrencyCode string
+}
+
+func (p *PriceInfo) populate(values map[string]string) error {
+	// The price is reported as separate integer and decimal parts.
+	intPart, decPart := values["price_int"], values["price_dec"]
+	if v, ok := values["ret"]; ok && v != returnOk {
+		return &DeviceError{Ret: v}
+	}
+	if intPart == "" {
+		return nil
+	}
+	s := intPart
+	if decPart != "" {
+		s += "." + decPart
+	}
+	price, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return &ParseError{Key: "price_int", Value: s, Err: err}
+	}
+	p.Price = price
+	return nil
+}
+
+// EstimateCost returns the cost of the given energy consumption in kWh, eg
+// from WeekPowerInfo.TotalWeek.
+func (p *PriceInfo) EstimateCost(energy float64) float64 {
+	return energy * p.Price
+}
+
+func (p *PriceInfo) String() string {
+	return fmt.Sprintf("price: %g %s\n", p.Price, p.CurrencyCode)
+}
+
+// GetPrice gets the electricity price configured on the unit.
+func (d *Daikin) GetPrice() error {
+	return d.GetPriceContext(context.Background())
+}
+
+// GetPriceContext gets the electricity price configured on the unit, using
+// the given context.
+func (d *Daikin) GetPriceContext(ctx context.Context) error {
+	p := &PriceInfo{}
+	if err := d.fetch(ctx, uriGetPrice, p); err != nil {
+		return err
+	}
+	d.PriceInfo = p
+	return nil
+}
@@ -0,0 +1,143 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// programDays are the keys for each day of the program, indexed by
+// time.Weekday.
+var programDays = [7]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// ProgramSlot is a time slot in the weekly program, from which the unit
+// runs with the given settings until the next slot.
+type ProgramSlot struct {
+	// StartTime is the offset from midnight the slot starts, at minute
+	// resolution.
+	StartTime time.Duration
+	// Temperature is the set temperature during the slot.
+	Temperature Temperature
+	// Mode is the operating mode during the slot.
+	Mode Mode
+	// Fan is the fan speed during the slot.
+	Fan Fan
+}
+
+// Program represents the weekly operating schedule of the unit.
+//
+// Each day is reported as a "/" separated list of slots, each encoded as
+// "start-mode-stemp-f_rate" with the start in minutes since midnight, eg
+// "mon=420-3-24.0-A/1320-4-20.0-B".
+type Program struct {
+	// Days are the slots for each day, indexed by time.Weekday.
+	Days [7][]ProgramSlot
+}
+
+func (s *ProgramSlot) encode() string {
+	return strings.Join([]string{
+		encodeTimeOfDay(s.StartTime),
+		strconv.Itoa(int(s.Mode)),
+		s.Temperature.Round().celsius(),
+		string(s.Fan),
+	}, "-")
+}
+
+func (s *ProgramSlot) decode(v string) error {
+	f := strings.Split(v, "-")
+	if len(f) != 4 {
+		return fmt.Errorf("malformed program slot %q", v)
+	}
+	if err := decodeTimeOfDay(&s.StartTime, f[0]); err != nil {
+		return err
+	}
+	if err := s.Mode.decode(f[1]); err != nil {
+		return err
+	}
+	if err := s.Temperature.decode(f[2]); err != nil {
+		return err
+	}
+	return s.Fan.decode(f[3])
+}
+
+func (p *Program) urlValues() url.Values {
+	qStr := url.Values{}
+	for day, slots := range p.Days {
+		enc := make([]string, len(slots))
+		for i := range slots {
+			enc[i] = slots[i].encode()
+		}
+		qStr.Set(programDays[day], strings.Join(enc, "/"))
+	}
+	return qStr
+}
+
+func (p *Program) populate(values map[string]string) error {
+	for k, v := range values {
+		if k == "ret" {
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+			continue
+		}
+		for day, key := range programDays {
+			if k != key {
+				continue
+			}
+			p.Days[day] = nil
+			if v == "" {
+				continue
+			}
+			for _, sv := range strings.Split(v, "/") {
+				var s ProgramSlot
+				if err := s.decode(sv); err != nil {
+					return &ParseError{Key: k, Value: v, Err: err}
+				}
+				p.Days[day] = append(p.Days[day], s)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Program) String() string {
+	var b strings.Builder
+	for day, slots := range p.Days {
+		fmt.Fprintf(&b, "%s:", programDays[day])
+		for _, s := range slots {
+			fmt.Fprintf(&b, " %s %s %s %s;", formatTimeOfDay(s.StartTime), s.Mode.String(), s.Temperature.String(), s.Fan.String())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// GetProgram gets the weekly program for the unit.
+func (d *Daikin) GetProgram() error {
+	return d.GetProgramContext(context.Background())
+}
+
+// GetProgramContext gets the weekly program for the unit, using the given
+// context.
+func (d *Daikin) GetProgramContext(ctx context.Context) error {
+	p := &Program{}
+	if err := d.fetch(ctx, uriGetProgram, p); err != nil {
+		return err
+	}
+	d.Program = p
+	return nil
+}
+
+// SetProgram configures the current weekly program to the unit.
+func (d *Daikin) SetProgram() error {
+	return d.SetProgramContext(context.Background())
+}
+
+// SetProgramContext configures the current weekly program to the unit,
+// using the given context.
+func (d *Daikin) SetProgramContext(ctx context.Context) error {
+	return d.post(ctx, uriSetProgram, d.Program.urlValues())
+}
@@ -0,0 +1,1159 @@
+// Messages for serializing the state of Daikin units, and for the
+// DaikinService defined in grpcpb/service.proto.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: daikin.proto
+
+package daikinpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Power is the power status of a unit.
+type Power int32
+
+const (
+	Power_POWER_OFF Power = 0
+	Power_POWER_ON  Power = 1
+)
+
+// Enum value maps for Power.
+var (
+	Power_name = map[int32]string{
+		0: "POWER_OFF",
+		1: "POWER_ON",
+	}
+	Power_value = map[string]int32{
+		"POWER_OFF": 0,
+		"POWER_ON":  1,
+	}
+)
+
+func (x Power) Enum() *Power {
+	p := new(Power)
+	*p = x
+	return p
+}
+
+func (x Power) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Power) Descriptor() protoreflect.EnumDescriptor {
+	return file_daikin_proto_enumTypes[0].Descriptor()
+}
+
+func (Power) Type() protoreflect.EnumType {
+	return &file_daikin_proto_enumTypes[0]
+}
+
+func (x Power) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Power.Descriptor instead.
+func (Power) EnumDescriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{0}
+}
+
+// Mode is the operating mode of a unit. Values match the unit protocol.
+type Mode int32
+
+const (
+	Mode_MODE_AUTO       Mode = 0
+	Mode_MODE_AUTO1      Mode = 1
+	Mode_MODE_DEHUMIDIFY Mode = 2
+	Mode_MODE_COOL       Mode = 3
+	Mode_MODE_HEAT       Mode = 4
+	Mode_MODE_FAN        Mode = 6
+	Mode_MODE_AUTO7      Mode = 7
+)
+
+// Enum value maps for Mode.
+var (
+	Mode_name = map[int32]string{
+		0: "MODE_AUTO",
+		1: "MODE_AUTO1",
+		2: "MODE_DEHUMIDIFY",
+		3: "MODE_COOL",
+		4: "MODE_HEAT",
+		6: "MODE_FAN",
+		7: "MODE_AUTO7",
+	}
+	Mode_value = map[string]int32{
+		"MODE_AUTO":       0,
+		"MODE_AUTO1":      1,
+		"MODE_DEHUMIDIFY": 2,
+		"MODE_COOL":       3,
+		"MODE_HEAT":       4,
+		"MODE_FAN":        6,
+		"MODE_AUTO7":      7,
+	}
+)
+
+func (x Mode) Enum() *Mode {
+	p := new(Mode)
+	*p = x
+	return p
+}
+
+func (x Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_daikin_proto_enumTypes[1].Descriptor()
+}
+
+func (Mode) Type() protoreflect.EnumType {
+	return &file_daikin_proto_enumTypes[1]
+}
+
+func (x Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Mode.Descriptor instead.
+func (Mode) EnumDescriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{1}
+}
+
+// Fan is the fan speed of a unit.
+type Fan int32
+
+const (
+	Fan_FAN_AUTO   Fan = 0
+	Fan_FAN_SILENT Fan = 1
+	Fan_FAN_1      Fan = 2
+	Fan_FAN_2      Fan = 3
+	Fan_FAN_3      Fan = 4
+	Fan_FAN_4      Fan = 5
+	Fan_FAN_5      Fan = 6
+)
+
+// Enum value maps for Fan.
+var (
+	Fan_name = map[int32]string{
+		0: "FAN_AUTO",
+		1: "FAN_SILENT",
+		2: "FAN_1",
+		3: "FAN_2",
+		4: "FAN_3",
+		5: "FAN_4",
+		6: "FAN_5",
+	}
+	Fan_value = map[string]int32{
+		"FAN_AUTO":   0,
+		"FAN_SILENT": 1,
+		"FAN_1":      2,
+		"FAN_2":      3,
+		"FAN_3":      4,
+		"FAN_4":      5,
+		"FAN_5":      6,
+	}
+)
+
+func (x Fan) Enum() *Fan {
+	p := new(Fan)
+	*p = x
+	return p
+}
+
+func (x Fan) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Fan) Descriptor() protoreflect.EnumDescriptor {
+	return file_daikin_proto_enumTypes[2].Descriptor()
+}
+
+func (Fan) Type() protoreflect.EnumType {
+	return &file_daikin_proto_enumTypes[2]
+}
+
+func (x Fan) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Fan.Descriptor instead.
+func (Fan) EnumDescriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{2}
+}
+
+// FanDir is the louvre swing setting of a unit. Values match the unit
+// protocol.
+type FanDir int32
+
+const (
+	FanDir_FAN_DIR_STOPPED    FanDir = 0
+	FanDir_FAN_DIR_VERTICAL   FanDir = 1
+	FanDir_FAN_DIR_HORIZONTAL FanDir = 2
+	FanDir_FAN_DIR_BOTH       FanDir = 3
+	FanDir_FAN_DIR_FIXED_LOW  FanDir = 8
+	FanDir_FAN_DIR_FIXED_MID  FanDir = 9
+	FanDir_FAN_DIR_FIXED_HIGH FanDir = 10
+)
+
+// Enum value maps for FanDir.
+var (
+	FanDir_name = map[int32]string{
+		0:  "FAN_DIR_STOPPED",
+		1:  "FAN_DIR_VERTICAL",
+		2:  "FAN_DIR_HORIZONTAL",
+		3:  "FAN_DIR_BOTH",
+		8:  "FAN_DIR_FIXED_LOW",
+		9:  "FAN_DIR_FIXED_MID",
+		10: "FAN_DIR_FIXED_HIGH",
+	}
+	FanDir_value = map[string]int32{
+		"FAN_DIR_STOPPED":    0,
+		"FAN_DIR_VERTICAL":   1,
+		"FAN_DIR_HORIZONTAL": 2,
+		"FAN_DIR_BOTH":       3,
+		"FAN_DIR_FIXED_LOW":  8,
+		"FAN_DIR_FIXED_MID":  9,
+		"FAN_DIR_FIXED_HIGH": 10,
+	}
+)
+
+func (x FanDir) Enum() *FanDir {
+	p := new(FanDir)
+	*p = x
+	return p
+}
+
+func (x FanDir) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FanDir) Descriptor() protoreflect.EnumDescriptor {
+	return file_daikin_proto_enumTypes[3].Descriptor()
+}
+
+func (FanDir) Type() protoreflect.EnumType {
+	return &file_daikin_proto_enumTypes[3]
+}
+
+func (x FanDir) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FanDir.Descriptor instead.
+func (FanDir) EnumDescriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{3}
+}
+
+// ControlInfo is the control settings of a unit.
+type ControlInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Power  Power  `protobuf:"varint,1,opt,name=power,proto3,enum=daikin.Power" json:"power,omitempty"`
+	Mode   Mode   `protobuf:"varint,2,opt,name=mode,proto3,enum=daikin.Mode" json:"mode,omitempty"`
+	Fan    Fan    `protobuf:"varint,3,opt,name=fan,proto3,enum=daikin.Fan" json:"fan,omitempty"`
+	FanDir FanDir `protobuf:"varint,4,opt,name=fan_dir,json=fanDir,proto3,enum=daikin.FanDir" json:"fan_dir,omitempty"`
+	// Set temperature in Celsius.
+	Temperature float64 `protobuf:"fixed64,5,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	// Set humidity in percent.
+	Humidity int32 `protobuf:"varint,6,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	// Optional features, only supported by some models.
+	Powerful       bool `protobuf:"varint,7,opt,name=powerful,proto3" json:"powerful,omitempty"`
+	Eco            bool `protobuf:"varint,8,opt,name=eco,proto3" json:"eco,omitempty"`
+	Streamer       bool `protobuf:"varint,9,opt,name=streamer,proto3" json:"streamer,omitempty"`
+	ComfortAirflow bool `protobuf:"varint,10,opt,name=comfort_airflow,json=comfortAirflow,proto3" json:"comfort_airflow,omitempty"`
+	IntelligentEye bool `protobuf:"varint,11,opt,name=intelligent_eye,json=intelligentEye,proto3" json:"intelligent_eye,omitempty"`
+}
+
+func (x *ControlInfo) Reset() {
+	*x = ControlInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daikin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControlInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlInfo) ProtoMessage() {}
+
+func (x *ControlInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_daikin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlInfo.ProtoReflect.Descriptor instead.
+func (*ControlInfo) Descriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ControlInfo) GetPower() Power {
+	if x != nil {
+		return x.Power
+	}
+	return Power_POWER_OFF
+}
+
+func (x *ControlInfo) GetMode() Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return Mode_MODE_AUTO
+}
+
+func (x *ControlInfo) GetFan() Fan {
+	if x != nil {
+		return x.Fan
+	}
+	return Fan_FAN_AUTO
+}
+
+func (x *ControlInfo) GetFanDir() FanDir {
+	if x != nil {
+		return x.FanDir
+	}
+	return FanDir_FAN_DIR_STOPPED
+}
+
+func (x *ControlInfo) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *ControlInfo) GetHumidity() int32 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *ControlInfo) GetPowerful() bool {
+	if x != nil {
+		return x.Powerful
+	}
+	return false
+}
+
+func (x *ControlInfo) GetEco() bool {
+	if x != nil {
+		return x.Eco
+	}
+	return false
+}
+
+func (x *ControlInfo) GetStreamer() bool {
+	if x != nil {
+		return x.Streamer
+	}
+	return false
+}
+
+func (x *ControlInfo) GetComfortAirflow() bool {
+	if x != nil {
+		return x.ComfortAirflow
+	}
+	return false
+}
+
+func (x *ControlInfo) GetIntelligentEye() bool {
+	if x != nil {
+		return x.IntelligentEye
+	}
+	return false
+}
+
+// SensorInfo is the sensor values of a unit.
+type SensorInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Indoor temperature in Celsius.
+	HomeTemperature float64 `protobuf:"fixed64,1,opt,name=home_temperature,json=homeTemperature,proto3" json:"home_temperature,omitempty"`
+	// Outdoor temperature in Celsius.
+	OutsideTemperature float64 `protobuf:"fixed64,2,opt,name=outside_temperature,json=outsideTemperature,proto3" json:"outside_temperature,omitempty"`
+	// Indoor humidity in percent, or -1 if not reported.
+	Humidity int32 `protobuf:"varint,3,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	// Compressor frequency in Hz, if reported.
+	CompressorFrequency *int32 `protobuf:"varint,4,opt,name=compressor_frequency,json=compressorFrequency,proto3,oneof" json:"compressor_frequency,omitempty"`
+	// Instantaneous power consumption in W, if reported.
+	InstantPower *int32 `protobuf:"varint,5,opt,name=instant_power,json=instantPower,proto3,oneof" json:"instant_power,omitempty"`
+	// Whether the filter needs cleaning or replacement.
+	FilterDirty bool `protobuf:"varint,6,opt,name=filter_dirty,json=filterDirty,proto3" json:"filter_dirty,omitempty"`
+}
+
+func (x *SensorInfo) Reset() {
+	*x = SensorInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daikin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SensorInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensorInfo) ProtoMessage() {}
+
+func (x *SensorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_daikin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensorInfo.ProtoReflect.Descriptor instead.
+func (*SensorInfo) Descriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SensorInfo) GetHomeTemperature() float64 {
+	if x != nil {
+		return x.HomeTemperature
+	}
+	return 0
+}
+
+func (x *SensorInfo) GetOutsideTemperature() float64 {
+	if x != nil {
+		return x.OutsideTemperature
+	}
+	return 0
+}
+
+func (x *SensorInfo) GetHumidity() int32 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *SensorInfo) GetCompressorFrequency() int32 {
+	if x != nil && x.CompressorFrequency != nil {
+		return *x.CompressorFrequency
+	}
+	return 0
+}
+
+func (x *SensorInfo) GetInstantPower() int32 {
+	if x != nil && x.InstantPower != nil {
+		return *x.InstantPower
+	}
+	return 0
+}
+
+func (x *SensorInfo) GetFilterDirty() bool {
+	if x != nil {
+		return x.FilterDirty
+	}
+	return false
+}
+
+// SensorSnapshot is the sensor and control state of a unit at a point in
+// time.
+type SensorSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SensorInfo  *SensorInfo  `protobuf:"bytes,1,opt,name=sensor_info,json=sensorInfo,proto3" json:"sensor_info,omitempty"`
+	ControlInfo *ControlInfo `protobuf:"bytes,2,opt,name=control_info,json=controlInfo,proto3" json:"control_info,omitempty"`
+	Address     string       `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// The time the state was fetched.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *SensorSnapshot) Reset() {
+	*x = SensorSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daikin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SensorSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensorSnapshot) ProtoMessage() {}
+
+func (x *SensorSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_daikin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensorSnapshot.ProtoReflect.Descriptor instead.
+func (*SensorSnapshot) Descriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SensorSnapshot) GetSensorInfo() *SensorInfo {
+	if x != nil {
+		return x.SensorInfo
+	}
+	return nil
+}
+
+func (x *SensorSnapshot) GetControlInfo() *ControlInfo {
+	if x != nil {
+		return x.ControlInfo
+	}
+	return nil
+}
+
+func (x *SensorSnapshot) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SensorSnapshot) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// Device is a unit served.
+type Device struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Id identifies the unit in requests.
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Name    string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daikin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_daikin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daikin_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daikin_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{4}
+}
+
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daikin_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daikin_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type GetControlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+}
+
+func (x *GetControlRequest) Reset() {
+	*x = GetControlRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daikin_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetControlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetControlRequest) ProtoMessage() {}
+
+func (x *GetControlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daikin_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetControlRequest.ProtoReflect.Descriptor instead.
+func (*GetControlRequest) Descriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetControlRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type SetControlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	// The settings to configure. All fields are set on the unit.
+	Control *ControlInfo `protobuf:"bytes,2,opt,name=control,proto3" json:"control,omitempty"`
+}
+
+func (x *SetControlRequest) Reset() {
+	*x = SetControlRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daikin_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetControlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetControlRequest) ProtoMessage() {}
+
+func (x *SetControlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daikin_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetControlRequest.ProtoReflect.Descriptor instead.
+func (*SetControlRequest) Descriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetControlRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *SetControlRequest) GetControl() *ControlInfo {
+	if x != nil {
+		return x.Control
+	}
+	return nil
+}
+
+type GetSensorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeviceId string `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+}
+
+func (x *GetSensorRequest) Reset() {
+	*x = GetSensorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_daikin_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSensorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSensorRequest) ProtoMessage() {}
+
+func (x *GetSensorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daikin_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSensorRequest.ProtoReflect.Descriptor instead.
+func (*GetSensorRequest) Descriptor() ([]byte, []int) {
+	return file_daikin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSensorRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+var File_daikin_proto protoreflect.FileDescriptor
+
+var file_daikin_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf6, 0x02, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x23, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x64, 0x61, 0x69,
+	0x6b, 0x69, 0x6e, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1d,
+	0x0a, 0x03, 0x66, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x64, 0x61,
+	0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x46, 0x61, 0x6e, 0x52, 0x03, 0x66, 0x61, 0x6e, 0x12, 0x27, 0x0a,
+	0x07, 0x66, 0x61, 0x6e, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e,
+	0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x46, 0x61, 0x6e, 0x44, 0x69, 0x72, 0x52, 0x06,
+	0x66, 0x61, 0x6e, 0x44, 0x69, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x75, 0x6d, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x68, 0x75, 0x6d, 0x69,
+	0x64, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x66, 0x75, 0x6c,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x66, 0x75, 0x6c,
+	0x12, 0x10, 0x0a, 0x03, 0x65, 0x63, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x65,
+	0x63, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x72, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x72, 0x12, 0x27,
+	0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x66, 0x6f, 0x72, 0x74, 0x5f, 0x61, 0x69, 0x72, 0x66, 0x6c, 0x6f,
+	0x77, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x66, 0x6f, 0x72, 0x74,
+	0x41, 0x69, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x6c,
+	0x6c, 0x69, 0x67, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x79, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x6c, 0x6c, 0x69, 0x67, 0x65, 0x6e, 0x74, 0x45, 0x79, 0x65,
+	0x22, 0xb4, 0x02, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x29, 0x0a, 0x10, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x68, 0x6f, 0x6d, 0x65, 0x54,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x6f, 0x75,
+	0x74, 0x73, 0x69, 0x64, 0x65, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x73, 0x69, 0x64, 0x65,
+	0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68,
+	0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x68,
+	0x75, 0x6d, 0x69, 0x64, 0x69, 0x74, 0x79, 0x12, 0x36, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x6f, 0x72, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x6f, 0x72, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x88, 0x01, 0x01, 0x12,
+	0x28, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x74, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x5f, 0x64, 0x69, 0x72, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x44, 0x69, 0x72, 0x74, 0x79, 0x42, 0x17, 0x0a, 0x15,
+	0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x5f, 0x66, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x74, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x22, 0xd1, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x33, 0x0a, 0x0b, 0x73, 0x65,
+	0x6e, 0x73, 0x6f, 0x72, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x73, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x36, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x46, 0x0a, 0x06, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x13, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x28, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x30, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x5f, 0x0a, 0x11,
+	0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x2d,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x22, 0x2f, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x2a, 0x24,
+	0x0a, 0x05, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x4f, 0x57, 0x45, 0x52,
+	0x5f, 0x4f, 0x46, 0x46, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f,
+	0x4f, 0x4e, 0x10, 0x01, 0x2a, 0x76, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0d, 0x0a, 0x09,
+	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4d,
+	0x4f, 0x44, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x31, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x4d,
+	0x4f, 0x44, 0x45, 0x5f, 0x44, 0x45, 0x48, 0x55, 0x4d, 0x49, 0x44, 0x49, 0x46, 0x59, 0x10, 0x02,
+	0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x4f, 0x4f, 0x4c, 0x10, 0x03, 0x12,
+	0x0d, 0x0a, 0x09, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x48, 0x45, 0x41, 0x54, 0x10, 0x04, 0x12, 0x0c,
+	0x0a, 0x08, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x46, 0x41, 0x4e, 0x10, 0x06, 0x12, 0x0e, 0x0a, 0x0a,
+	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x37, 0x10, 0x07, 0x2a, 0x5a, 0x0a, 0x03,
+	0x46, 0x61, 0x6e, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x41, 0x4e, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x10,
+	0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x46, 0x41, 0x4e, 0x5f, 0x53, 0x49, 0x4c, 0x45, 0x4e, 0x54, 0x10,
+	0x01, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x41, 0x4e, 0x5f, 0x31, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05,
+	0x46, 0x41, 0x4e, 0x5f, 0x32, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x41, 0x4e, 0x5f, 0x33,
+	0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x41, 0x4e, 0x5f, 0x34, 0x10, 0x05, 0x12, 0x09, 0x0a,
+	0x05, 0x46, 0x41, 0x4e, 0x5f, 0x35, 0x10, 0x06, 0x2a, 0xa3, 0x01, 0x0a, 0x06, 0x46, 0x61, 0x6e,
+	0x44, 0x69, 0x72, 0x12, 0x13, 0x0a, 0x0f, 0x46, 0x41, 0x4e, 0x5f, 0x44, 0x49, 0x52, 0x5f, 0x53,
+	0x54, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x41, 0x4e, 0x5f,
+	0x44, 0x49, 0x52, 0x5f, 0x56, 0x45, 0x52, 0x54, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x16,
+	0x0a, 0x12, 0x46, 0x41, 0x4e, 0x5f, 0x44, 0x49, 0x52, 0x5f, 0x48, 0x4f, 0x52, 0x49, 0x5a, 0x4f,
+	0x4e, 0x54, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x41, 0x4e, 0x5f, 0x44, 0x49,
+	0x52, 0x5f, 0x42, 0x4f, 0x54, 0x48, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x41, 0x4e, 0x5f,
+	0x44, 0x49, 0x52, 0x5f, 0x46, 0x49, 0x58, 0x45, 0x44, 0x5f, 0x4c, 0x4f, 0x57, 0x10, 0x08, 0x12,
+	0x15, 0x0a, 0x11, 0x46, 0x41, 0x4e, 0x5f, 0x44, 0x49, 0x52, 0x5f, 0x46, 0x49, 0x58, 0x45, 0x44,
+	0x5f, 0x4d, 0x49, 0x44, 0x10, 0x09, 0x12, 0x16, 0x0a, 0x12, 0x46, 0x41, 0x4e, 0x5f, 0x44, 0x49,
+	0x52, 0x5f, 0x46, 0x49, 0x58, 0x45, 0x44, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x10, 0x0a, 0x42, 0x2f,
+	0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x75, 0x78,
+	0x74, 0x72, 0x6f, 0x6e, 0x69, 0x78, 0x2f, 0x67, 0x6f, 0x2d, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_daikin_proto_rawDescOnce sync.Once
+	file_daikin_proto_rawDescData = file_daikin_proto_rawDesc
+)
+
+func file_daikin_proto_rawDescGZIP() []byte {
+	file_daikin_proto_rawDescOnce.Do(func() {
+		file_daikin_proto_rawDescData = protoimpl.X.CompressGZIP(file_daikin_proto_rawDescData)
+	})
+	return file_daikin_proto_rawDescData
+}
+
+var file_daikin_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_daikin_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_daikin_proto_goTypes = []any{
+	(Power)(0),                    // 0: daikin.Power
+	(Mode)(0),                     // 1: daikin.Mode
+	(Fan)(0),                      // 2: daikin.Fan
+	(FanDir)(0),                   // 3: daikin.FanDir
+	(*ControlInfo)(nil),           // 4: daikin.ControlInfo
+	(*SensorInfo)(nil),            // 5: daikin.SensorInfo
+	(*SensorSnapshot)(nil),        // 6: daikin.SensorSnapshot
+	(*Device)(nil),                // 7: daikin.Device
+	(*ListDevicesRequest)(nil),    // 8: daikin.ListDevicesRequest
+	(*ListDevicesResponse)(nil),   // 9: daikin.ListDevicesResponse
+	(*GetControlRequest)(nil),     // 10: daikin.GetControlRequest
+	(*SetControlRequest)(nil),     // 11: daikin.SetControlRequest
+	(*GetSensorRequest)(nil),      // 12: daikin.GetSensorRequest
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+}
+var file_daikin_proto_depIdxs = []int32{
+	0,  // 0: daikin.ControlInfo.power:type_name -> daikin.Power
+	1,  // 1: daikin.ControlInfo.mode:type_name -> daikin.Mode
+	2,  // 2: daikin.ControlInfo.fan:type_name -> daikin.Fan
+	3,  // 3: daikin.ControlInfo.fan_dir:type_name -> daikin.FanDir
+	5,  // 4: daikin.SensorSnapshot.sensor_info:type_name -> daikin.SensorInfo
+	4,  // 5: daikin.SensorSnapshot.control_info:type_name -> daikin.ControlInfo
+	13, // 6: daikin.SensorSnapshot.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 7: daikin.ListDevicesResponse.devices:type_name -> daikin.Device
+	4,  // 8: daikin.SetControlRequest.control:type_name -> daikin.ControlInfo
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_daikin_proto_init() }
+func file_daikin_proto_init() {
+	if File_daikin_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_daikin_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ControlInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daikin_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SensorInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daikin_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*SensorSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daikin_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Device); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daikin_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDevicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daikin_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDevicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daikin_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*GetControlRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daikin_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*SetControlRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_daikin_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*GetSensorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_daikin_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_daikin_proto_rawDesc,
+			NumEnums:      4,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_daikin_proto_goTypes,
+		DependencyIndexes: file_daikin_proto_depIdxs,
+		EnumInfos:         file_daikin_proto_enumTypes,
+		MessageInfos:      file_daikin_proto_msgTypes,
+	}.Build()
+	File_daikin_proto = out.File
+	file_daikin_proto_rawDesc = nil
+	file_daikin_proto_goTypes = nil
+	file_daikin_proto_depIdxs = nil
+}
@@ -0,0 +1,5 @@
+// Package daikinpb contains the protobuf messages generated from
+// daikin.proto. The gRPC service is in the grpcpb subpackage.
+package daikinpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative daikin.proto
@@ -0,0 +1,5 @@
+// Package grpcpb contains the gRPC service definitions generated from
+// service.proto, using the messages in daikinpb.
+package grpcpb
+
+//go:generate protoc -I .. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative grpcpb/service.proto
@@ -0,0 +1,103 @@
+// Service definition for remote control of Daikin units. It is kept apart
+// from the messages in daikin.proto, so that using the messages does not
+// depend on gRPC.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: grpcpb/service.proto
+
+package grpcpb
+
+import (
+	proto "github.com/buxtronix/go-daikin/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_grpcpb_service_proto protoreflect.FileDescriptor
+
+var file_grpcpb_service_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x67, 0x72, 0x70, 0x63, 0x70, 0x62, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x1a, 0x0c,
+	0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x8e, 0x02, 0x0a,
+	0x0d, 0x44, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x46,
+	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1a, 0x2e,
+	0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x64, 0x61, 0x69, 0x6b,
+	0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x12, 0x19, 0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x3c, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x12, 0x19, 0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x39, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x12,
+	0x18, 0x2e, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x73,
+	0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x64, 0x61, 0x69, 0x6b,
+	0x69, 0x6e, 0x2e, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x34, 0x5a,
+	0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x75, 0x78, 0x74,
+	0x72, 0x6f, 0x6e, 0x69, 0x78, 0x2f, 0x67, 0x6f, 0x2d, 0x64, 0x61, 0x69, 0x6b, 0x69, 0x6e, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x70, 0x62, 0x3b, 0x67, 0x72, 0x70,
+	0x63, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_grpcpb_service_proto_goTypes = []any{
+	(*proto.ListDevicesRequest)(nil),  // 0: daikin.ListDevicesRequest
+	(*proto.GetControlRequest)(nil),   // 1: daikin.GetControlRequest
+	(*proto.SetControlRequest)(nil),   // 2: daikin.SetControlRequest
+	(*proto.GetSensorRequest)(nil),    // 3: daikin.GetSensorRequest
+	(*proto.ListDevicesResponse)(nil), // 4: daikin.ListDevicesResponse
+	(*proto.ControlInfo)(nil),         // 5: daikin.ControlInfo
+	(*proto.SensorInfo)(nil),          // 6: daikin.SensorInfo
+}
+var file_grpcpb_service_proto_depIdxs = []int32{
+	0, // 0: daikin.DaikinService.ListDevices:input_type -> daikin.ListDevicesRequest
+	1, // 1: daikin.DaikinService.GetControl:input_type -> daikin.GetControlRequest
+	2, // 2: daikin.DaikinService.SetControl:input_type -> daikin.SetControlRequest
+	3, // 3: daikin.DaikinService.GetSensor:input_type -> daikin.GetSensorRequest
+	4, // 4: daikin.DaikinService.ListDevices:output_type -> daikin.ListDevicesResponse
+	5, // 5: daikin.DaikinService.GetControl:output_type -> daikin.ControlInfo
+	5, // 6: daikin.DaikinService.SetControl:output_type -> daikin.ControlInfo
+	6, // 7: daikin.DaikinService.GetSensor:output_type -> daikin.SensorInfo
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_grpcpb_service_proto_init() }
+func file_grpcpb_service_proto_init() {
+	if File_grpcpb_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_grpcpb_service_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_grpcpb_service_proto_goTypes,
+		DependencyIndexes: file_grpcpb_service_proto_depIdxs,
+	}.Build()
+	File_grpcpb_service_proto = out.File
+	file_grpcpb_service_proto_rawDesc = nil
+	file_grpcpb_service_proto_goTypes = nil
+	file_grpcpb_service_proto_depIdxs = nil
+}
@@ -0,0 +1,254 @@
+// Service definition for remote control of Daikin units. It is kept apart
+// from the messages in daikin.proto, so that using the messages does not
+// depend on gRPC.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: grpcpb/service.proto
+
+package grpcpb
+
+import (
+	context "context"
+	proto "github.com/buxtronix/go-daikin/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DaikinService_ListDevices_FullMethodName = "/daikin.DaikinService/ListDevices"
+	DaikinService_GetControl_FullMethodName  = "/daikin.DaikinService/GetControl"
+	DaikinService_SetControl_FullMethodName  = "/daikin.DaikinService/SetControl"
+	DaikinService_GetSensor_FullMethodName   = "/daikin.DaikinService/GetSensor"
+)
+
+// DaikinServiceClient is the client API for DaikinService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DaikinService controls a set of Daikin units.
+type DaikinServiceClient interface {
+	// ListDevices lists the units served.
+	ListDevices(ctx context.Context, in *proto.ListDevicesRequest, opts ...grpc.CallOption) (*proto.ListDevicesResponse, error)
+	// GetControl gets the current control settings of a unit.
+	GetControl(ctx context.Context, in *proto.GetControlRequest, opts ...grpc.CallOption) (*proto.ControlInfo, error)
+	// SetControl configures the control settings of a unit, and returns the
+	// resulting settings.
+	SetControl(ctx context.Context, in *proto.SetControlRequest, opts ...grpc.CallOption) (*proto.ControlInfo, error)
+	// GetSensor gets the current sensor values of a unit.
+	GetSensor(ctx context.Context, in *proto.GetSensorRequest, opts ...grpc.CallOption) (*proto.SensorInfo, error)
+}
+
+type daikinServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDaikinServiceClient(cc grpc.ClientConnInterface) DaikinServiceClient {
+	return &daikinServiceClient{cc}
+}
+
+func (c *daikinServiceClient) ListDevices(ctx context.Context, in *proto.ListDevicesRequest, opts ...grpc.CallOption) (*proto.ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(proto.ListDevicesResponse)
+	err := c.cc.Invoke(ctx, DaikinService_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daikinServiceClient) GetControl(ctx context.Context, in *proto.GetControlRequest, opts ...grpc.CallOption) (*proto.ControlInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(proto.ControlInfo)
+	err := c.cc.Invoke(ctx, DaikinService_GetControl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daikinServiceClient) SetControl(ctx context.Context, in *proto.SetControlRequest, opts ...grpc.CallOption) (*proto.ControlInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(proto.ControlInfo)
+	err := c.cc.Invoke(ctx, DaikinService_SetControl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daikinServiceClient) GetSensor(ctx context.Context, in *proto.GetSensorRequest, opts ...grpc.CallOption) (*proto.SensorInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(proto.SensorInfo)
+	err := c.cc.Invoke(ctx, DaikinService_GetSensor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DaikinServiceServer is the server API for DaikinService service.
+// All implementations must embed UnimplementedDaikinServiceServer
+// for forward compatibility.
+//
+// DaikinService controls a set of Daikin units.
+type DaikinServiceServer interface {
+	// ListDevices lists the units served.
+	ListDevices(context.Context, *proto.ListDevicesRequest) (*proto.ListDevicesResponse, error)
+	// GetControl gets the current control settings of a unit.
+	GetControl(context.Context, *proto.GetControlRequest) (*proto.ControlInfo, error)
+	// SetControl configures the control settings of a unit, and returns the
+	// resulting settings.
+	SetControl(context.Context, *proto.SetControlRequest) (*proto.ControlInfo, error)
+	// GetSensor gets the current sensor values of a unit.
+	GetSensor(context.Context, *proto.GetSensorRequest) (*proto.SensorInfo, error)
+	mustEmbedUnimplementedDaikinServiceServer()
+}
+
+// UnimplementedDaikinServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDaikinServiceServer struct{}
+
+func (UnimplementedDaikinServiceServer) ListDevices(context.Context, *proto.ListDevicesRequest) (*proto.ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedDaikinServiceServer) GetControl(context.Context, *proto.GetControlRequest) (*proto.ControlInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetControl not implemented")
+}
+func (UnimplementedDaikinServiceServer) SetControl(context.Context, *proto.SetControlRequest) (*proto.ControlInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetControl not implemented")
+}
+func (UnimplementedDaikinServiceServer) GetSensor(context.Context, *proto.GetSensorRequest) (*proto.SensorInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSensor not implemented")
+}
+func (UnimplementedDaikinServiceServer) mustEmbedUnimplementedDaikinServiceServer() {}
+func (UnimplementedDaikinServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeDaikinServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DaikinServiceServer will
+// result in compilation errors.
+type UnsafeDaikinServiceServer interface {
+	mustEmbedUnimplementedDaikinServiceServer()
+}
+
+func RegisterDaikinServiceServer(s grpc.ServiceRegistrar, srv DaikinServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDaikinServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DaikinService_ServiceDesc, srv)
+}
+
+func _DaikinService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(proto.ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaikinServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaikinService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaikinServiceServer).ListDevices(ctx, req.(*proto.ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaikinService_GetControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(proto.GetControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaikinServiceServer).GetControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaikinService_GetControl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaikinServiceServer).GetControl(ctx, req.(*proto.GetControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaikinService_SetControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(proto.SetControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaikinServiceServer).SetControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaikinService_SetControl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaikinServiceServer).SetControl(ctx, req.(*proto.SetControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaikinService_GetSensor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(proto.GetSensorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaikinServiceServer).GetSensor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DaikinService_GetSensor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaikinServiceServer).GetSensor(ctx, req.(*proto.GetSensorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DaikinService_ServiceDesc is the grpc.ServiceDesc for DaikinService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DaikinService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "daikin.DaikinService",
+	HandlerType: (*DaikinServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDevices",
+			Handler:    _DaikinService_ListDevices_Handler,
+		},
+		{
+			MethodName: "GetControl",
+			Handler:    _DaikinService_GetControl_Handler,
+		},
+		{
+			MethodName: "SetControl",
+			Handler:    _DaikinService_SetControl_Handler,
+		},
+		{
+			MethodName: "GetSensor",
+			Handler:    _DaikinService_GetSensor_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "grpcpb/service.proto",
+}
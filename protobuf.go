@@ -0,0 +1,152 @@
+package daikin
+
+import (
+	daikinpb "github.com/buxtronix/go-daikin/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fanProto maps fan speeds to their protobuf values.
+var fanProto = map[Fan]daikinpb.Fan{
+	FanAuto:   daikinpb.Fan_FAN_AUTO,
+	FanSilent: daikinpb.Fan_FAN_SILENT,
+	Fan1:      daikinpb.Fan_FAN_1,
+	Fan2:      daikinpb.Fan_FAN_2,
+	Fan3:      daikinpb.Fan_FAN_3,
+	Fan4:      daikinpb.Fan_FAN_4,
+	Fan5:      daikinpb.Fan_FAN_5,
+}
+
+// ToProto returns the control settings as a ControlInfo message.
+func (c *ControlInfo) ToProto() *daikinpb.ControlInfo {
+	return &daikinpb.ControlInfo{
+		Power:          daikinpb.Power(c.Power),
+		Mode:           daikinpb.Mode(c.Mode),
+		Fan:            fanProto[c.Fan],
+		FanDir:         daikinpb.FanDir(c.FanDir),
+		Temperature:    float64(c.Temperature),
+		Humidity:       int32(c.Humidity),
+		Powerful:       c.Powerful,
+		Eco:            c.Eco,
+		Streamer:       c.Streamer,
+		ComfortAirflow: c.ComfortAirflow,
+		IntelligentEye: c.IntelligentEye,
+	}
+}
+
+// FromProto sets the control settings from a ControlInfo message.
+func (c *ControlInfo) FromProto(p *daikinpb.ControlInfo) error {
+	*c = ControlInfo{
+		Power:          Power(p.GetPower()),
+		Mode:           Mode(p.GetMode()),
+		FanDir:         FanDir(p.GetFanDir()),
+		Temperature:    Temperature(p.GetTemperature()),
+		Humidity:       Humidity(p.GetHumidity()),
+		Powerful:       p.GetPowerful(),
+		Eco:            p.GetEco(),
+		Streamer:       p.GetStreamer(),
+		ComfortAirflow: p.GetComfortAirflow(),
+		IntelligentEye: p.GetIntelligentEye(),
+	}
+	for f, pf := range fanProto {
+		if pf == p.GetFan() {
+			c.Fan = f
+		}
+	}
+	if c.Fan == "" {
+		return &ErrUnknownValue{Type: "fan", Value: p.GetFan().String()}
+	}
+	return nil
+}
+
+// MarshalProto returns the control settings encoded as a ControlInfo
+// message from proto/daikin.proto.
+func (c *ControlInfo) MarshalProto() ([]byte, error) {
+	return proto.Marshal(c.ToProto())
+}
+
+// UnmarshalProto decodes control settings encoded by MarshalProto.
+func (c *ControlInfo) UnmarshalProto(b []byte) error {
+	p := &daikinpb.ControlInfo{}
+	if err := proto.Unmarshal(b, p); err != nil {
+		return err
+	}
+	return c.FromProto(p)
+}
+
+// ToProto returns the sensor values as a SensorInfo message.
+func (s *SensorInfo) ToProto() *daikinpb.SensorInfo {
+	p := &daikinpb.SensorInfo{
+		HomeTemperature:    float64(s.HomeTemperature),
+		OutsideTemperature: float64(s.OutsideTemperature),
+		Humidity:           int32(s.Humidity),
+		FilterDirty:        s.FilterDirty,
+	}
+	if s.CompressorFrequency != nil {
+		p.CompressorFrequency = proto.Int32(int32(*s.CompressorFrequency))
+	}
+	if s.InstantPower != nil {
+		p.InstantPower = proto.Int32(int32(*s.InstantPower))
+	}
+	return p
+}
+
+// FromProto sets the sensor values from a SensorInfo message.
+func (s *SensorInfo) FromProto(p *daikinpb.SensorInfo) {
+	*s = SensorInfo{
+		HomeTemperature:    Temperature(p.GetHomeTemperature()),
+		OutsideTemperature: Temperature(p.GetOutsideTemperature()),
+		Humidity:           Humidity(p.GetHumidity()),
+		FilterDirty:        p.GetFilterDirty(),
+	}
+	if p.CompressorFrequency != nil {
+		f := int(p.GetCompressorFrequency())
+		s.CompressorFrequency = &f
+	}
+	if p.InstantPower != nil {
+		w := int(p.GetInstantPower())
+		s.InstantPower = &w
+	}
+}
+
+// MarshalProto returns the sensor values encoded as a SensorInfo message
+// from proto/daikin.proto.
+func (s *SensorInfo) MarshalProto() ([]byte, error) {
+	return proto.Marshal(s.ToProto())
+}
+
+// UnmarshalProto decodes sensor values encoded by MarshalProto.
+func (s *SensorInfo) UnmarshalProto(b []byte) error {
+	p := &daikinpb.SensorInfo{}
+	if err := proto.Unmarshal(b, p); err != nil {
+		return err
+	}
+	s.FromProto(p)
+	return nil
+}
+
+// MarshalProto returns the snapshot encoded as a SensorSnapshot message
+// from proto/daikin.proto.
+func (s *SensorSnapshot) MarshalProto() ([]byte, error) {
+	return proto.Marshal(&daikinpb.SensorSnapshot{
+		SensorInfo:  s.SensorInfo.ToProto(),
+		ControlInfo: s.ControlInfo.ToProto(),
+		Address:     s.Address,
+		Timestamp:   timestamppb.New(s.Timestamp),
+	})
+}
+
+// UnmarshalProto decodes a snapshot encoded by MarshalProto.
+func (s *SensorSnapshot) UnmarshalProto(b []byte) error {
+	p := &daikinpb.SensorSnapshot{}
+	if err := proto.Unmarshal(b, p); err != nil {
+		return err
+	}
+	if err := s.ControlInfo.FromProto(p.GetControlInfo()); err != nil {
+		return err
+	}
+	s.SensorInfo.FromProto(p.GetSensorInfo())
+	s.Address = p.GetAddress()
+	s.Timestamp = p.GetTimestamp().AsTime()
+	return nil
+}
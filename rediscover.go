@@ -0,0 +1,58 @@
+package daikin
+
+import (
+	"context"
+	"errors"
+	"syscall"
+)
+
+// WithAutoRediscover configures the unit to find its new address when it
+// becomes unreachable, eg after DHCP assigns it a new IP. When a request
+// fails because the connection is refused or the host is unreachable,
+// discovery is run on the given network, and if the unit's MAC address is
+// found at a new address, Address is updated and the request retried once.
+// The unit's BasicInfo must have been fetched, to know its MAC address.
+func WithAutoRediscover(network *DaikinNetwork) DeviceOption {
+	return func(d *Daikin) {
+		d.rediscover = network
+	}
+}
+
+// unreachable returns whether err indicates the unit is no longer at its
+// address.
+func unreachable(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EHOSTUNREACH)
+}
+
+// refreshAddress runs discovery to find the unit at a new address. It
+// returns whether the address changed.
+func (d *Daikin) refreshAddress(ctx context.Context) bool {
+	n := d.rediscover
+	if d.BasicInfo == nil || d.BasicInfo.MAC == "" {
+		return false
+	}
+	mac := d.BasicInfo.MAC
+	old := d.address()
+
+	// Serialise discovery, so units failing together only run it once.
+	n.rediscoverMu.Lock()
+	defer n.rediscoverMu.Unlock()
+	if d.address() != old {
+		return true
+	}
+	if dev, ok := n.DeviceByMAC(mac); !ok || dev.Address == old {
+		n.log().Info("device unreachable, rediscovering", "address", old, "mac", mac)
+		// Poll at least once, even for networks of fixed addresses.
+		if err := n.discover(ctx, max(n.PollCount, 1)); err != nil {
+			n.log().Error("rediscovery failed", "address", old, "err", err)
+			return false
+		}
+	}
+	dev, ok := n.DeviceByMAC(mac)
+	if !ok || dev.Address == old {
+		return false
+	}
+	n.log().Info("device found at new address", "mac", mac, "from", old, "to", dev.Address)
+	d.setAddress(dev.Address)
+	return true
+}
@@ -0,0 +1,121 @@
+// Package registry persists discovered Daikin devices to a JSON file, so
+// they are usable immediately after a restart without waiting for
+// discovery.
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+// entry is the saved state of a single unit.
+type entry struct {
+	Address     string              `json:"address"`
+	Name        daikin.Name         `json:"name,omitempty"`
+	Token       string              `json:"token,omitempty"`
+	MAC         string              `json:"mac,omitempty"`
+	ControlInfo *daikin.ControlInfo `json:"control_info,omitempty"`
+}
+
+// Registry is a JSON file of saved units.
+type Registry struct {
+	// Path is the path of the file.
+	Path string
+}
+
+// Load returns the units saved in the registry, with their address,
+// name, token and last known control settings. It returns no units if the
+// file does not exist.
+func (r *Registry) Load() ([]*daikin.Daikin, error) {
+	b, err := os.ReadFile(r.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []entry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, err
+	}
+	devices := make([]*daikin.Daikin, 0, len(entries))
+	for _, e := range entries {
+		d := &daikin.Daikin{
+			Address:     e.Address,
+			Name:        e.Name,
+			Token:       e.Token,
+			ControlInfo: e.ControlInfo,
+		}
+		if e.MAC != "" {
+			d.BasicInfo = &daikin.BasicInfo{MAC: e.MAC, Name: e.Name}
+		}
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+// Save replaces the units saved in the registry. The file is written
+// atomically, and is only readable by the owner as it contains tokens.
+func (r *Registry) Save(devices []*daikin.Daikin) error {
+	entries := make([]entry, 0, len(devices))
+	for _, d := range devices {
+		e := entry{
+			Address:     d.Address,
+			Name:        d.Name,
+			Token:       d.Token,
+			ControlInfo: d.ControlInfo,
+		}
+		if d.BasicInfo != nil {
+			e.MAC = d.BasicInfo.MAC
+		}
+		entries = append(entries, e)
+	}
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(r.Path), filepath.Base(r.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), r.Path)
+}
+
+// RegistryOption returns an option for daikin.NewNetwork which adds the
+// units saved in the registry at path. Units already added, eg by
+// daikin.AddressTokenOption, are kept as is. Discovery still runs, to find
+// new units and any which have changed address. If the registry can't be
+// loaded, a warning is logged with the network's logger and no units are
+// added.
+func RegistryOption(path string) daikin.Option {
+	return func(n *daikin.DaikinNetwork) {
+		r := &Registry{Path: path}
+		devices, err := r.Load()
+		if err != nil {
+			n.Logger().Warn("can't load device registry", "path", path, "err", err)
+			return
+		}
+		for _, d := range devices {
+			// AddDevice fails for units already added, leaving them as is.
+			n.AddDevice(d.Address, func(dev *daikin.Daikin) {
+				dev.Name = d.Name
+				dev.Token = d.Token
+				dev.BasicInfo = d.BasicInfo
+				dev.ControlInfo = d.ControlInfo
+			})
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+)
+
+// RemoteMethodInfo represents how the unit is being controlled.
+type RemoteMethodInfo struct {
+	// Method is the control method reported by the unit, eg "home only".
+	Method string
+}
+
+func (r *RemoteMethodInfo) populate(values map[string]string) error {
+	for k, v := range values {
+		switch k {
+		case "method":
+			r.Method = v
+		case "ret":
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *RemoteMethodInfo) String() string {
+	return fmt.Sprintf("method: %s\n", r.Method)
+}
+
+// GetRemoteMethod gets how the unit is being controlled. A change in method
+// may indicate the unit was changed with the physical remote, and state
+// should be fetched again.
+func (d *Daikin) GetRemoteMethod() error {
+	return d.GetRemoteMethodContext(context.Background())
+}
+
+// GetRemoteMethodContext gets how the unit is being controlled, using the
+// given context.
+func (d *Daikin) GetRemoteMethodContext(ctx context.Context) error {
+	r := &RemoteMethodInfo{}
+	if err := d.fetch(ctx, uriGetRemoteMethod, r); err != nil {
+		return err
+	}
+	d.RemoteMethodInfo = r
+	return nil
+}
@@ -0,0 +1,69 @@
+package daikin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"time"
+)
+
+// attemptKey is the context key for the attempt number of a request.
+type attemptKey struct{}
+
+// AttemptFromContext returns the attempt number, starting at 1, of the
+// request to a unit with the given context. It is for use by HTTP
+// transports, and returns 0 for other contexts.
+func AttemptFromContext(ctx context.Context) int {
+	a, _ := ctx.Value(attemptKey{}).(int)
+	return a
+}
+
+// do sends a request to the unit and returns the parsed response. Transient
+// network failures are retried with exponential backoff, up to MaxAttempts.
+// If the unit is unreachable and configured with WithAutoRediscover, the
+// request is retried once more at its new address, if found.
+func (d *Daikin) do(ctx context.Context, method, uri, form string) (map[string]string, error) {
+	vals, err := d.doRetry(ctx, method, uri, form)
+	if err != nil && d.rediscover != nil && ctx.Err() == nil && unreachable(err) && d.refreshAddress(ctx) {
+		return d.doRetry(ctx, method, uri, form)
+	}
+	return vals, err
+}
+
+// doRetry sends a request to the unit, retrying transient network failures
+// with exponential backoff, up to MaxAttempts.
+func (d *Daikin) doRetry(ctx context.Context, method, uri, form string) (map[string]string, error) {
+	delay := d.RetryDelay
+	for attempt := 1; ; attempt++ {
+		vals, err := d.doOnce(context.WithValue(ctx, attemptKey{}, attempt), method, uri, form)
+		if err == nil || attempt >= d.MaxAttempts || ctx.Err() != nil || !retryable(err) {
+			return vals, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// retryable returns whether err is a transient network error worth retrying.
+// Malformed responses and errors returned by the device are not retried.
+func retryable(err error) bool {
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		return false
+	}
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	}
+	var nErr net.Error
+	return errors.As(err, &nErr) && nErr.Timeout()
+}
@@ -0,0 +1,131 @@
+package daikin_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/buxtronix/go-daikin"
+	"github.com/buxtronix/go-daikin/daikintest"
+)
+
+// flakyTransport fails the first failures requests with a connection
+// reset, and records the attempt number of each request.
+type flakyTransport struct {
+	failures int
+
+	mu       sync.Mutex
+	attempts []int
+}
+
+func (t *flakyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.attempts = append(t.attempts, daikin.AttemptFromContext(r.Context()))
+	fail := len(t.attempts) <= t.failures
+	t.mu.Unlock()
+	if fail {
+		return nil, fmt.Errorf("read: %w", syscall.ECONNRESET)
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestRetry(t *testing.T) {
+	var (
+		devErr   *daikin.DeviceError
+		parseErr *daikin.ParseError
+	)
+	tests := []struct {
+		name     string
+		failures int
+		// body, if set, is the unit's response.
+		body         string
+		wantAttempts int
+		wantErr      func(error) bool
+	}{
+		{
+			name:         "success",
+			wantAttempts: 1,
+		},
+		{
+			name:         "transient failures",
+			failures:     2,
+			wantAttempts: 3,
+		},
+		{
+			name:         "attempts exhausted",
+			failures:     5,
+			wantAttempts: 3,
+			wantErr:      func(err error) bool { return errors.Is(err, syscall.ECONNRESET) },
+		},
+		{
+			name:         "device error",
+			body:         "ret=PARAM NG",
+			wantAttempts: 1,
+			wantErr:      func(err error) bool { return errors.As(err, &devErr) },
+		},
+		{
+			name:         "parse error",
+			body:         "ret=OK,model",
+			wantAttempts: 1,
+			wantErr:      func(err error) bool { return errors.As(err, &parseErr) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := daikintest.NewMockDevice(t)
+			if tt.body != "" {
+				m.SetResponse("/aircon/get_model_info", tt.body)
+			}
+			tr := &flakyTransport{failures: tt.failures}
+			d := m.Device()
+			d.HTTPClient = &http.Client{Transport: tr}
+			d.MaxAttempts = 3
+			d.RetryDelay = time.Millisecond
+
+			err := d.GetModelInfo()
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Errorf("GetModelInfo() = %v", err)
+			case tt.wantErr != nil && !tt.wantErr(err):
+				t.Errorf("GetModelInfo() = %v, want matching error", err)
+			}
+			want := make([]int, tt.wantAttempts)
+			for i := range want {
+				want[i] = i + 1
+			}
+			if fmt.Sprint(tr.attempts) != fmt.Sprint(want) {
+				t.Errorf("attempts = %v, want %v", tr.attempts, want)
+			}
+		})
+	}
+}
+
+// TestRetryCancelDuringBackoff checks that a request waiting to retry
+// returns once its context is done.
+func TestRetryCancelDuringBackoff(t *testing.T) {
+	m := daikintest.NewMockDevice(t)
+	tr := &flakyTransport{failures: 1}
+	d := m.Device()
+	d.HTTPClient = &http.Client{Transport: tr}
+	d.MaxAttempts = 3
+	d.RetryDelay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := d.GetModelInfoContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetModelInfoContext() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("GetModelInfoContext() returned after %v, want on cancellation", elapsed)
+	}
+	if len(tr.attempts) != 1 {
+		t.Errorf("attempts = %v, want [1]", tr.attempts)
+	}
+}
@@ -0,0 +1,198 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/buxtronix/go-daikin"
+	"gopkg.in/yaml.v3"
+)
+
+// fields are the sensor values conditions may test.
+var fields = map[string]func(daikin.SensorInfo) float64{
+	"indoor_temperature":  func(s daikin.SensorInfo) float64 { return float64(s.HomeTemperature) },
+	"outdoor_temperature": func(s daikin.SensorInfo) float64 { return float64(s.OutsideTemperature) },
+	"humidity":            func(s daikin.SensorInfo) float64 { return float64(s.Humidity) },
+}
+
+// ops are the comparisons conditions may make.
+var ops = map[string]func(a, b float64) bool{
+	">":  func(a, b float64) bool { return a > b },
+	">=": func(a, b float64) bool { return a >= b },
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	"==": func(a, b float64) bool { return a == b },
+	"!=": func(a, b float64) bool { return a != b },
+}
+
+// ConditionSpec is the serializable form of a Condition. Exactly one of
+// And, Or, Not or a comparison of Field with Value should be set.
+type ConditionSpec struct {
+	// And is true if all of its conditions are true.
+	And []ConditionSpec `json:"and,omitempty" yaml:"and,omitempty"`
+	// Or is true if any of its conditions are true.
+	Or []ConditionSpec `json:"or,omitempty" yaml:"or,omitempty"`
+	// Not is true if its condition is false.
+	Not *ConditionSpec `json:"not,omitempty" yaml:"not,omitempty"`
+	// Field is the sensor value to compare: indoor_temperature,
+	// outdoor_temperature or humidity.
+	Field string `json:"field,omitempty" yaml:"field,omitempty"`
+	// Op is the comparison: >, >=, <, <=, == or !=.
+	Op string `json:"op,omitempty" yaml:"op,omitempty"`
+	// Value is the value Field is compared with.
+	Value float64 `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// Condition returns the Condition described by the spec.
+func (c *ConditionSpec) Condition() (Condition, error) {
+	switch {
+	case len(c.And) > 0:
+		conds, err := conditions(c.And)
+		if err != nil {
+			return nil, err
+		}
+		return And(conds...), nil
+	case len(c.Or) > 0:
+		conds, err := conditions(c.Or)
+		if err != nil {
+			return nil, err
+		}
+		return Or(conds...), nil
+	case c.Not != nil:
+		cond, err := c.Not.Condition()
+		if err != nil {
+			return nil, err
+		}
+		return Not(cond), nil
+	}
+	field, ok := fields[c.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", c.Field)
+	}
+	op, ok := ops[c.Op]
+	if !ok {
+		return nil, fmt.Errorf("unknown op %q", c.Op)
+	}
+	v := c.Value
+	return func(s daikin.SensorInfo) bool { return op(field(s), v) }, nil
+}
+
+func conditions(specs []ConditionSpec) ([]Condition, error) {
+	conds := make([]Condition, len(specs))
+	for i := range specs {
+		var err error
+		if conds[i], err = specs[i].Condition(); err != nil {
+			return nil, err
+		}
+	}
+	return conds, nil
+}
+
+// ActionSpec is the serializable form of an action, changing the control
+// settings of the unit. Settings are given by name, eg mode "cool", and
+// omitted settings are left unchanged.
+type ActionSpec struct {
+	Power       string   `json:"power,omitempty" yaml:"power,omitempty"`
+	Mode        string   `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty" yaml:"temperature,omitempty"`
+	Fan         string   `json:"fan,omitempty" yaml:"fan,omitempty"`
+	FanDir      string   `json:"fan_dir,omitempty" yaml:"fan_dir,omitempty"`
+}
+
+// Action returns the action described by the spec. The action fetches the
+// current control settings of the unit, and sets the unit only if they
+// change.
+func (a *ActionSpec) Action() (func(context.Context, *daikin.Daikin) error, error) {
+	var apply []func(*daikin.ControlInfo)
+	if a.Power != "" {
+		p, err := daikin.ParsePower(a.Power)
+		if err != nil {
+			return nil, err
+		}
+		apply = append(apply, func(c *daikin.ControlInfo) { c.Power = p })
+	}
+	if a.Mode != "" {
+		m, err := daikin.ParseMode(a.Mode)
+		if err != nil {
+			return nil, err
+		}
+		apply = append(apply, func(c *daikin.ControlInfo) { c.Mode = m })
+	}
+	if a.Temperature != nil {
+		t := daikin.Temperature(*a.Temperature)
+		apply = append(apply, func(c *daikin.ControlInfo) { c.Temperature = t })
+	}
+	if a.Fan != "" {
+		f, err := daikin.ParseFan(a.Fan)
+		if err != nil {
+			return nil, err
+		}
+		apply = append(apply, func(c *daikin.ControlInfo) { c.Fan = f })
+	}
+	if a.FanDir != "" {
+		fd, err := daikin.ParseFanDir(a.FanDir)
+		if err != nil {
+			return nil, err
+		}
+		apply = append(apply, func(c *daikin.ControlInfo) { c.FanDir = fd })
+	}
+	return func(ctx context.Context, d *daikin.Daikin) error {
+		if err := d.GetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		desired := *d.ControlInfo
+		for _, f := range apply {
+			f(&desired)
+		}
+		if len(daikin.Diff(*d.ControlInfo, desired)) == 0 {
+			return nil
+		}
+		d.ControlInfo = &desired
+		return d.SetControlInfoContext(ctx)
+	}, nil
+}
+
+// RuleSpec is the serializable form of a Rule.
+type RuleSpec struct {
+	// Name identifies the rule in errors.
+	Name string `json:"name" yaml:"name"`
+	// When is the condition for the rule to apply.
+	When ConditionSpec `json:"when" yaml:"when"`
+	// Then is the action taken when the rule applies.
+	Then ActionSpec `json:"then" yaml:"then"`
+}
+
+// Rule returns the Rule described by the spec.
+func (r *RuleSpec) Rule() (Rule, error) {
+	cond, err := r.When.Condition()
+	if err != nil {
+		return Rule{}, fmt.Errorf("rule %q: %v", r.Name, err)
+	}
+	action, err := r.Then.Action()
+	if err != nil {
+		return Rule{}, fmt.Errorf("rule %q: %v", r.Name, err)
+	}
+	return Rule{Name: r.Name, Condition: cond, Action: action}, nil
+}
+
+// Config is the serializable form of a rule set.
+type Config struct {
+	// Rules are the rules, in evaluation order.
+	Rules []RuleSpec `json:"rules" yaml:"rules"`
+}
+
+// Parse parses a rule set Config from JSON or YAML.
+func Parse(data []byte) ([]Rule, error) {
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	rules := make([]Rule, len(c.Rules))
+	for i := range c.Rules {
+		var err error
+		if rules[i], err = c.Rules[i].Rule(); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
@@ -0,0 +1,109 @@
+// Package rules automates Daikin units with conditional rules, eg "if the
+// indoor temperature is above 28°C, cool to 24°C".
+//
+// Rules may be built in Go from a Condition and an Action, or loaded from
+// JSON or YAML with Parse. An example YAML rule set:
+//
+//	rules:
+//	  - name: cool when hot
+//	    when:
+//	      field: indoor_temperature
+//	      op: ">"
+//	      value: 28
+//	    then:
+//	      power: "on"
+//	      mode: cool
+//	      temperature: 24
+//	  - name: off when mild
+//	    when:
+//	      and:
+//	        - {field: indoor_temperature, op: ">=", value: 20}
+//	        - {field: indoor_temperature, op: "<=", value: 24}
+//	    then:
+//	      power: "off"
+package rules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+// Condition reports whether a rule applies to the given sensor values.
+type Condition func(daikin.SensorInfo) bool
+
+// And returns a Condition true if all of conds are true.
+func And(conds ...Condition) Condition {
+	return func(s daikin.SensorInfo) bool {
+		for _, c := range conds {
+			if !c(s) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Condition true if any of conds are true.
+func Or(conds ...Condition) Condition {
+	return func(s daikin.SensorInfo) bool {
+		for _, c := range conds {
+			if c(s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Condition true if cond is false.
+func Not(cond Condition) Condition {
+	return func(s daikin.SensorInfo) bool {
+		return !cond(s)
+	}
+}
+
+// Rule is an action to take on a unit when a condition holds.
+type Rule struct {
+	// Name identifies the rule in errors.
+	Name string
+	// Condition reports whether the rule applies.
+	Condition Condition
+	// Action is run on the unit when the rule applies, with the context
+	// given to Evaluate.
+	Action func(ctx context.Context, d *daikin.Daikin) error
+}
+
+// Engine evaluates a set of rules.
+type Engine struct {
+	// Rules are evaluated in order.
+	Rules []Rule
+}
+
+// NewEngine returns an Engine evaluating the given rules.
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{Rules: rules}
+}
+
+// Evaluate fetches the sensor values of the unit, and runs the action of
+// each rule whose condition holds. It is intended to be called on each
+// poll cycle. Actions of later rules are run even if earlier ones fail,
+// and all errors are returned.
+func (e *Engine) Evaluate(ctx context.Context, d *daikin.Daikin) error {
+	if err := d.GetSensorInfoContext(ctx); err != nil {
+		return err
+	}
+	s := *d.SensorInfo
+	var errs []error
+	for _, r := range e.Rules {
+		if !r.Condition(s) {
+			continue
+		}
+		if err := r.Action(ctx, d); err != nil {
+			errs = append(errs, fmt.Errorf("rule %q: %w", r.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// scdlTimerDays are the keys for each day of the schedule timer, indexed
+// by time.Weekday.
+var scdlTimerDays = [7]string{"suc", "moc", "tuc", "wec", "thc", "frc", "sac"}
+
+// ScdlTimerDay is the schedule timer setting for a day of the week. Times
+// are the offset from midnight, at minute resolution.
+type ScdlTimerDay struct {
+	// Enabled is whether the timer runs on this day.
+	Enabled bool
+	// OnTime is the time of day the unit turns on.
+	OnTime time.Duration
+	// OffTime is the time of day the unit turns off.
+	OffTime time.Duration
+}
+
+// ScdlTimer represents the schedule timer of the unit, which turns it on
+// and off at the same times each week. Unlike TimerInfo, it recurs.
+//
+// Each day is reported as "enabled/on/off" with times in minutes since
+// midnight, eg "moc=1/420/1320".
+type ScdlTimer struct {
+	// Enabled is whether the schedule timer is enabled.
+	Enabled bool
+	// Days are the settings for each day, indexed by time.Weekday.
+	Days [7]ScdlTimerDay
+}
+
+// Enable sets the unit to turn on at onAt and off at offAt on the given day,
+// and enables the schedule timer.
+func (s *ScdlTimer) Enable(day time.Weekday, onAt, offAt time.Duration) {
+	s.Days[day] = ScdlTimerDay{Enabled: true, OnTime: onAt, OffTime: offAt}
+	s.Enabled = true
+}
+
+func (s *ScdlTimerDay) decode(v string) error {
+	f := strings.Split(v, "/")
+	if len(f) != 3 {
+		return fmt.Errorf("malformed schedule timer day %q", v)
+	}
+	if err := decodeBool(&s.Enabled, "en", f[0]); err != nil {
+		return err
+	}
+	if err := decodeTimeOfDay(&s.OnTime, f[1]); err != nil {
+		return err
+	}
+	return decodeTimeOfDay(&s.OffTime, f[2])
+}
+
+func (s *ScdlTimer) urlValues() url.Values {
+	qStr := url.Values{}
+	qStr.Set("en_scdltimer", encodeBool(s.Enabled))
+	for day, d := range s.Days {
+		qStr.Set(scdlTimerDays[day], strings.Join([]string{
+			encodeBool(d.Enabled), encodeTimeOfDay(d.OnTime), encodeTimeOfDay(d.OffTime),
+		}, "/"))
+	}
+	return qStr
+}
+
+func (s *ScdlTimer) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "en_scdltimer":
+			err = decodeBool(&s.Enabled, k, v)
+		case "ret":
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+		default:
+			for day, key := range scdlTimerDays {
+				if k == key {
+					err = s.Days[day].decode(v)
+				}
+			}
+		}
+		if err != nil {
+			return &ParseError{Key: k, Value: v, Err: err}
+		}
+	}
+	return nil
+}
+
+func (s *ScdlTimer) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "scdltimer: %t\n", s.Enabled)
+	for day, d := range s.Days {
+		if d.Enabled {
+			fmt.Fprintf(&b, "%s: %s-%s\n", time.Weekday(day), formatTimeOfDay(d.OnTime), formatTimeOfDay(d.OffTime))
+		}
+	}
+	return b.String()
+}
+
+// GetScdlTimer gets the schedule timer settings for the unit.
+func (d *Daikin) GetScdlTimer() error {
+	return d.GetScdlTimerContext(context.Background())
+}
+
+// GetScdlTimerContext gets the schedule timer settings for the unit, using
+// the given context.
+func (d *Daikin) GetScdlTimerContext(ctx context.Context) error {
+	s := &ScdlTimer{}
+	if err := d.fetch(ctx, uriGetScdlTimer, s); err != nil {
+		return err
+	}
+	d.ScdlTimer = s
+	return nil
+}
+
+// SetScdlTimer configures the current schedule timer settings to the unit.
+func (d *Daikin) SetScdlTimer() error {
+	return d.SetScdlTimerContext(context.Background())
+}
+
+// SetScdlTimerContext configures the current schedule timer settings to
+// the unit, using the given context.
+func (d *Daikin) SetScdlTimerContext(ctx context.Context) error {
+	return d.post(ctx, uriSetScdlTimer, d.ScdlTimer.urlValues())
+}
@@ -0,0 +1,61 @@
+package daikin
+
+import "context"
+
+// Scene is a named set of control settings, applied to all devices on a
+// DaikinNetwork by SetScene.
+type Scene string
+
+// Built in scenes. They may be replaced with RegisterScene.
+const (
+	SceneHome  Scene = "home"
+	SceneAway  Scene = "away"
+	SceneNight Scene = "night"
+	SceneBoost Scene = "boost"
+)
+
+// defaultScenes are the control settings for the built in scenes.
+var defaultScenes = map[Scene]ControlInfo{
+	SceneHome:  {Power: PowerOn, Mode: ModeAuto, Temperature: 22, Fan: FanAuto, FanDir: FanDirStopped},
+	SceneAway:  {Power: PowerOff, Mode: ModeAuto, Temperature: 22, Fan: FanAuto, FanDir: FanDirStopped},
+	SceneNight: {Power: PowerOn, Mode: ModeAuto, Temperature: 20, Fan: FanSilent, FanDir: FanDirStopped},
+	SceneBoost: {Power: PowerOn, Mode: ModeAuto, Temperature: 22, Fan: Fan5, FanDir: FanDirBoth},
+}
+
+// RegisterScene registers the control settings for the named scene,
+// replacing any existing scene of that name. It is safe to call
+// concurrently with SetScene.
+func (d *DaikinNetwork) RegisterScene(name string, info ControlInfo) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.scenes == nil {
+		d.scenes = map[Scene]ControlInfo{}
+	}
+	d.scenes[Scene(name)] = info
+}
+
+// scene returns the control settings for the given scene.
+func (d *DaikinNetwork) scene(s Scene) (ControlInfo, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if info, ok := d.scenes[s]; ok {
+		return info, true
+	}
+	info, ok := defaultScenes[s]
+	return info, ok
+}
+
+// SetScene concurrently configures all devices with the control settings
+// of the given scene. It returns an error if the scene is unknown, and
+// otherwise the errors of any devices which failed, keyed by address.
+func (d *DaikinNetwork) SetScene(ctx context.Context, scene Scene) (map[string]error, error) {
+	info, ok := d.scene(scene)
+	if !ok {
+		return nil, &ErrUnknownValue{Type: "scene", Value: string(scene)}
+	}
+	return forEachDevice(d.devices(), d.Concurrency, func(dev *Daikin) error {
+		c := info
+		dev.ControlInfo = &c
+		return dev.SetControlInfoContext(ctx)
+	}), nil
+}
@@ -0,0 +1,58 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// NightSetback lowers the set temperature overnight. It is a simpler
+// alternative to a Program for the common case of a single night window.
+type NightSetback struct {
+	// DaytimeTemp is the set temperature outside the night window.
+	DaytimeTemp Temperature
+	// NightTemp is the set temperature within the night window.
+	NightTemp Temperature
+	// StartHour is the hour of day the night window starts, 0-23.
+	StartHour int
+	// EndHour is the hour of day the night window ends, 0-23. It may be
+	// before StartHour, for a window spanning midnight.
+	EndHour int
+}
+
+// IsNight returns whether t is within the night window, in t's location.
+func (ns NightSetback) IsNight(t time.Time) bool {
+	h := t.Hour()
+	if ns.StartHour <= ns.EndHour {
+		return h >= ns.StartHour && h < ns.EndHour
+	}
+	return h >= ns.StartHour || h < ns.EndHour
+}
+
+// Temperature returns the set temperature for time t.
+func (ns NightSetback) Temperature(t time.Time) Temperature {
+	if ns.IsNight(t) {
+		return ns.NightTemp
+	}
+	return ns.DaytimeTemp
+}
+
+// ApplySetback configures the unit with the set temperature for now,
+// leaving other control settings unchanged. The unit is only updated if
+// the set temperature differs.
+func (d *Daikin) ApplySetback(ctx context.Context, ns NightSetback, now time.Time) error {
+	for _, h := range []int{ns.StartHour, ns.EndHour} {
+		if h < 0 || h > 23 {
+			return fmt.Errorf("setback hour %d is not within 0-23", h)
+		}
+	}
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return err
+	}
+	t := ns.Temperature(now)
+	if d.ControlInfo.Temperature == t {
+		return nil
+	}
+	d.ControlInfo.Temperature = t
+	return d.SetControlInfoContext(ctx)
+}
@@ -0,0 +1,67 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// sleepStep is the set temperature change of each sleep timer step, the
+// resolution accepted by units.
+const sleepStep = 0.5
+
+// SleepTimer gradually shifts the set temperature of the unit overnight,
+// for sleep comfort.
+type SleepTimer struct {
+	// Duration is how long the temperature is shifted over.
+	Duration time.Duration
+	// TempDelta is the total change to the set temperature, eg -2 to
+	// lower it when heating, or 2 to raise it when cooling. It must be a
+	// multiple of 0.5 degrees.
+	TempDelta Temperature
+}
+
+// SetSleepTimer runs the sleep timer on the unit. Units do not support
+// this natively, so it is emulated by changing the set temperature in 0.5
+// degree steps spread evenly over the duration. It blocks until the timer
+// completes, the context is done, or the unit is turned off.
+func (d *Daikin) SetSleepTimer(ctx context.Context, t SleepTimer) error {
+	if t.Duration <= 0 {
+		return fmt.Errorf("sleep duration %s is not positive", t.Duration)
+	}
+	if err := t.TempDelta.Validate(); err != nil {
+		return err
+	}
+	steps := int(math.Abs(float64(t.TempDelta)) / sleepStep)
+	if steps == 0 {
+		return nil
+	}
+	step := t.TempDelta / Temperature(steps)
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		return err
+	}
+	start := d.ControlInfo.Temperature
+
+	ticker := time.NewTicker(t.Duration / time.Duration(steps))
+	defer ticker.Stop()
+	for i := 1; i <= steps; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		// Fetch the current settings, so changes made meanwhile are kept.
+		if err := d.GetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		if d.ControlInfo.Power == PowerOff {
+			return nil
+		}
+		d.ControlInfo.Temperature = start + step*Temperature(i)
+		if err := d.SetControlInfoContext(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package daikin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// ssdpAddress is the SSDP multicast group.
+	ssdpAddress = "239.255.255.250:1900"
+	// ssdpSearchTarget is the search target of Daikin Wifi modules.
+	ssdpSearchTarget = "urn:schemas-daikin-com:device:Daikin:1"
+)
+
+// ssdpSearch is the M-SEARCH request sent to discover devices.
+var ssdpSearch = "M-SEARCH * HTTP/1.1\r\n" +
+	"HOST: " + ssdpAddress + "\r\n" +
+	"MAN: \"ssdp:discover\"\r\n" +
+	"MX: 1\r\n" +
+	"ST: " + ssdpSearchTarget + "\r\n\r\n"
+
+// DiscoverSSDPOption configures discovery to send UPnP/SSDP searches
+// instead of broadcasting. This suits networks with IGMP snooping, which
+// may not forward broadcasts.
+func DiscoverSSDPOption() func(*DaikinNetwork) {
+	return func(d *DaikinNetwork) {
+		d.SSDP = true
+	}
+}
+
+// ssdpLocalAddr returns the local address to send searches from, on the
+// first configured interface if any.
+func (d *DaikinNetwork) ssdpLocalAddr() (*net.UDPAddr, error) {
+	names := d.interfaces()
+	if len(names) == 0 {
+		return nil, nil
+	}
+	ifi, err := net.InterfaceByName(names[0])
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := ifi.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range addrs {
+		if ipn, ok := a.(*net.IPNet); ok && ipn.IP.To4() != nil {
+			return &net.UDPAddr{IP: ipn.IP}, nil
+		}
+	}
+	return nil, fmt.Errorf("no addresses on interface: %s", names[0])
+}
+
+// ssdpDeviceAddress returns the device address from the LOCATION header of
+// a search response.
+func ssdpDeviceAddress(resp []byte) (string, error) {
+	r, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(resp)), nil)
+	if err != nil {
+		return "", err
+	}
+	r.Body.Close()
+	loc := r.Header.Get("Location")
+	if loc == "" {
+		return "", fmt.Errorf("no LOCATION header")
+	}
+	u, err := url.Parse(loc)
+	if err != nil {
+		return "", err
+	}
+	if u.Port() == "" || u.Port() == "80" {
+		return u.Hostname(), nil
+	}
+	return u.Host, nil
+}
+
+// discoverSSDP runs a polling cycle of the given number of SSDP searches
+// for Daikin devices.
+func (d *DaikinNetwork) discoverSSDP(ctx context.Context, polls int) error {
+	lAddr, err := d.ssdpLocalAddr()
+	if err != nil {
+		return err
+	}
+	conn, err := net.ListenUDP("udp4", lAddr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := d.setReadBuffer(conn); err != nil {
+		return err
+	}
+	group, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if err != nil {
+		return err
+	}
+
+	// Unblock any pending read when the context is done.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetReadDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+
+	rBuf := make([]byte, readBufferSize)
+	for i := 0; i < polls && ctx.Err() == nil; i++ {
+		if _, err := conn.WriteToUDP([]byte(ssdpSearch), group); err != nil {
+			d.log().Error("SSDP: write failed", "err", err)
+		}
+		// Read until the deadline.
+		for {
+			conn.SetReadDeadline(time.Now().Add(d.readTimeout()))
+			if ctx.Err() != nil {
+				break
+			}
+			n, rAddr, err := conn.ReadFromUDP(rBuf)
+			if err != nil {
+				if err, ok := err.(net.Error); ok && err.Timeout() {
+					break
+				}
+				d.log().Error("SSDP: read failed", "err", err)
+				continue
+			}
+			addr, err := ssdpDeviceAddress(rBuf[:n])
+			if err != nil {
+				d.log().Debug("SSDP: can't parse reply, skipping", "from", rAddr, "err", err)
+				continue
+			}
+			d.log().Info("SSDP: found device", "address", addr)
+			d.addFound(addr)
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,162 @@
+// Package sse streams the state of Daikin units to browsers as
+// Server-Sent Events, for clients which cannot use WebSocket.
+//
+// Each unit is polled every interval, sending a "sensor" event with its
+// sensor values and a "control" event with its control settings. Failed
+// polls send an "error" event. The data of each event is a JSON object,
+// with an "id" field holding the key of the unit in the devices map.
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/buxtronix/go-daikin"
+)
+
+// defaultInterval is the event interval if NewSSEHandler is not given one.
+const defaultInterval = 10 * time.Second
+
+// Handler is an http.Handler streaming the state of units as Server-Sent
+// Events.
+type Handler struct {
+	devices  map[string]*daikin.Daikin
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+// LoggerOption configures the logger for errors. By default slog.Default()
+// is used.
+func LoggerOption(l *slog.Logger) func(*Handler) {
+	return func(h *Handler) {
+		h.logger = l
+	}
+}
+
+// NewSSEHandler returns a handler streaming the state of the devices, keyed
+// by their id, every interval. Each connection polls the devices
+// independently, until the client disconnects. If interval is not
+// positive, ten seconds is used.
+func NewSSEHandler(devices map[string]*daikin.Daikin, interval time.Duration, opts ...func(*Handler)) http.Handler {
+	h := &Handler{
+		devices:  devices,
+		interval: interval,
+		logger:   slog.Default(),
+	}
+	if h.interval <= 0 {
+		h.interval = defaultInterval
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+type sensorEvent struct {
+	ID string `json:"id"`
+	daikin.SensorInfo
+}
+
+type controlEvent struct {
+	ID string `json:"id"`
+	daikin.ControlInfo
+}
+
+type errorEvent struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+// event is an event to send to the client.
+type event struct {
+	name string
+	data interface{}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// The pollers stop when the client disconnects, or writing fails.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	events := make(chan event)
+	var wg sync.WaitGroup
+	for id, d := range h.devices {
+		wg.Add(1)
+		go func(id string, d *daikin.Daikin) {
+			defer wg.Done()
+			h.poll(ctx, id, d, events)
+		}(id, d)
+	}
+	// Stop the pollers before returning.
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	for {
+		select {
+		case e := <-events:
+			data, err := json.Marshal(e.data)
+			if err != nil {
+				h.logger.Error("encoding event failed", "err", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.name, data); err != nil {
+				h.logger.Debug("writing event failed", "err", err)
+				return
+			}
+			flusher.Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll sends the state of the device to events every interval, until ctx
+// is done.
+func (h *Handler) poll(ctx context.Context, id string, d *daikin.Daikin, events chan<- event) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		for _, e := range h.fetch(ctx, id, d) {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fetch returns the events for the current state of the device.
+func (h *Handler) fetch(ctx context.Context, id string, d *daikin.Daikin) []event {
+	snap, err := d.FetchSnapshot(ctx)
+	if err != nil {
+		return []event{{name: "error", data: errorEvent{ID: id, Error: err.Error()}}}
+	}
+	return []event{
+		{name: "sensor", data: sensorEvent{ID: id, SensorInfo: snap.SensorInfo}},
+		{name: "control", data: controlEvent{ID: id, ControlInfo: snap.ControlInfo}},
+	}
+}
@@ -0,0 +1,57 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+)
+
+// TargetInfo represents the target values reported by the unit, used by
+// some advanced control modes. Their semantics are largely unknown.
+//
+// Packet captures from a BRP072A42 module show only "ret=OK,target=0".
+// All values other than ret are kept in Raw.
+type TargetInfo struct {
+	// Target is the reported target value.
+	Target int
+	// Raw contains all reported values, keyed by name.
+	Raw map[string]string
+}
+
+func (t *TargetInfo) populate(values map[string]string) error {
+	t.Raw = map[string]string{}
+	for k, v := range values {
+		switch k {
+		case "target":
+			if err := decodeInt(&t.Target, v); err != nil {
+				return &ParseError{Key: k, Value: v, Err: err}
+			}
+		case "ret":
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+			continue
+		}
+		t.Raw[k] = v
+	}
+	return nil
+}
+
+func (t *TargetInfo) String() string {
+	return fmt.Sprintf("target: %d\nraw: %v\n", t.Target, t.Raw)
+}
+
+// GetTarget gets the target values for the unit.
+func (d *Daikin) GetTarget() error {
+	return d.GetTargetContext(context.Background())
+}
+
+// GetTargetContext gets the target values for the unit, using the given
+// context.
+func (d *Daikin) GetTargetContext(ctx context.Context) error {
+	t := &TargetInfo{}
+	if err := d.fetch(ctx, uriGetTarget, t); err != nil {
+		return err
+	}
+	d.TargetInfo = t
+	return nil
+}
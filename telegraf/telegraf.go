@@ -0,0 +1,108 @@
+// Package telegraf is a Telegraf input plugin reporting the state of Daikin
+// units. Importing it registers the "daikin" input, configured as:
+//
+//	[[inputs.daikin]]
+//	  ## Addresses of the units. If empty, units are discovered.
+//	  addresses = ["192.168.1.50"]
+//
+// Each unit reports a "daikin" measurement tagged with its name and
+// address. The fields match the metrics package: up, indoor_temperature,
+// outdoor_temperature, indoor_humidity, set_temperature, power_state, mode
+// and fan_speed. A unit which can't be queried reports only up as 0.
+package telegraf
+
+import (
+	"context"
+	"sync"
+
+	"github.com/buxtronix/go-daikin"
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+)
+
+const sampleConfig = `
+  ## Addresses of the units. If empty, units are discovered.
+  addresses = ["192.168.1.50"]
+`
+
+// Daikin is the Telegraf input for Daikin units.
+type Daikin struct {
+	// Addresses are the addresses of the units. If empty, units are
+	// discovered on the first gather.
+	Addresses []string `toml:"addresses"`
+
+	network *daikin.DaikinNetwork
+}
+
+func init() {
+	inputs.Add("daikin", func() telegraf.Input { return &Daikin{} })
+}
+
+// SampleConfig implements telegraf.Input.
+func (*Daikin) SampleConfig() string {
+	return sampleConfig
+}
+
+// Init implements telegraf.Initializer.
+func (d *Daikin) Init() error {
+	var opts []daikin.Option
+	for _, a := range d.Addresses {
+		opts = append(opts, daikin.AddressTokenOption(a, ""))
+	}
+	n, err := daikin.NewNetwork(opts...)
+	if err != nil {
+		return err
+	}
+	d.network = n
+	return nil
+}
+
+// Gather implements telegraf.Input.
+func (d *Daikin) Gather(acc telegraf.Accumulator) error {
+	if len(d.network.DeviceList()) == 0 {
+		if err := d.network.Discover(); err != nil {
+			return err
+		}
+	}
+	var wg sync.WaitGroup
+	for _, dev := range d.network.DeviceList() {
+		wg.Add(1)
+		go func(dev *daikin.Daikin) {
+			defer wg.Done()
+			gatherDevice(acc, dev)
+		}(dev)
+	}
+	wg.Wait()
+	return nil
+}
+
+func gatherDevice(acc telegraf.Accumulator, d *daikin.Daikin) {
+	ctx := context.Background()
+	tags := map[string]string{"name": d.Name.String(), "address": d.Address}
+	if err := d.GetControlInfoContext(ctx); err != nil {
+		acc.AddError(err)
+		acc.AddGauge("daikin", map[string]interface{}{"up": 0}, tags)
+		return
+	}
+	if err := d.GetSensorInfoContext(ctx); err != nil {
+		acc.AddError(err)
+		acc.AddGauge("daikin", map[string]interface{}{"up": 0}, tags)
+		return
+	}
+	s, ci := d.SensorInfo, d.ControlInfo
+	fields := map[string]interface{}{
+		"up":                  1,
+		"indoor_temperature":  float64(s.HomeTemperature),
+		"outdoor_temperature": float64(s.OutsideTemperature),
+		"set_temperature":     float64(ci.Temperature),
+		"power_state":         int(ci.Power),
+		"mode":                int(ci.Mode),
+	}
+	if s.Humidity >= 0 {
+		fields["indoor_humidity"] = int(s.Humidity)
+	}
+	if v, ok := ci.Fan.Level(); ok {
+		fields["fan_speed"] = v
+	}
+	acc.AddGauge("daikin", fields, tags)
+}
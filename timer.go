@@ -0,0 +1,112 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TimerInfo represents the on/off timer settings of the unit. Times are
+// the offset from midnight, at minute resolution.
+type TimerInfo struct {
+	// OnEnabled is whether the on timer is enabled.
+	OnEnabled bool
+	// OffEnabled is whether the off timer is enabled.
+	OffEnabled bool
+	// OnTime is the time of day the unit turns on.
+	OnTime time.Duration
+	// OffTime is the time of day the unit turns off.
+	OffTime time.Duration
+}
+
+// decodeTimeOfDay decodes a minutes-since-midnight value into t.
+func decodeTimeOfDay(t *time.Duration, s string) error {
+	var m int
+	if err := decodeInt(&m, s); err != nil {
+		return err
+	}
+	*t = time.Duration(m) * time.Minute
+	return nil
+}
+
+func encodeTimeOfDay(t time.Duration) string {
+	return strconv.Itoa(int(t / time.Minute))
+}
+
+func encodeBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (t *TimerInfo) urlValues() url.Values {
+	qStr := url.Values{}
+	qStr.Set("fthr_on", encodeBool(t.OnEnabled))
+	qStr.Set("fthr_off", encodeBool(t.OffEnabled))
+	qStr.Set("dtim_on", encodeTimeOfDay(t.OnTime))
+	qStr.Set("dtim_off", encodeTimeOfDay(t.OffTime))
+	return qStr
+}
+
+func (t *TimerInfo) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "fthr_on":
+			err = decodeBool(&t.OnEnabled, k, v)
+		case "fthr_off":
+			err = decodeBool(&t.OffEnabled, k, v)
+		case "dtim_on":
+			err = decodeTimeOfDay(&t.OnTime, v)
+		case "dtim_off":
+			err = decodeTimeOfDay(&t.OffTime, v)
+		case "ret":
+			if v != returnOk {
+				return &DeviceError{Ret: v}
+			}
+		}
+		if err != nil {
+			return &ParseError{Key: k, Value: v, Err: err}
+		}
+	}
+	return nil
+}
+
+func formatTimeOfDay(t time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(t/time.Hour), int(t%time.Hour/time.Minute))
+}
+
+func (t *TimerInfo) String() string {
+	return fmt.Sprintf("timer_on: %t (%s)\ntimer_off: %t (%s)\n",
+		t.OnEnabled, formatTimeOfDay(t.OnTime), t.OffEnabled, formatTimeOfDay(t.OffTime))
+}
+
+// GetTimer gets the current on/off timer settings for the unit.
+func (d *Daikin) GetTimer() error {
+	return d.GetTimerContext(context.Background())
+}
+
+// GetTimerContext gets the current on/off timer settings for the unit,
+// using the given context.
+func (d *Daikin) GetTimerContext(ctx context.Context) error {
+	t := &TimerInfo{}
+	if err := d.fetch(ctx, uriGetTimer, t); err != nil {
+		return err
+	}
+	d.TimerInfo = t
+	return nil
+}
+
+// SetTimer configures the current timer settings to the unit.
+func (d *Daikin) SetTimer() error {
+	return d.SetTimerContext(context.Background())
+}
+
+// SetTimerContext configures the current timer settings to the unit, using
+// the given context.
+func (d *Daikin) SetTimerContext(ctx context.Context) error {
+	return d.post(ctx, uriSetTimer, d.TimerInfo.urlValues())
+}
@@ -0,0 +1,62 @@
+package daikin_test
+
+import (
+	"testing"
+
+	"github.com/buxtronix/go-daikin"
+	"github.com/buxtronix/go-daikin/daikintest"
+)
+
+func TestTokenHeader(t *testing.T) {
+	const token = "0123456789abcdef"
+	m := daikintest.NewMockDevice(t)
+	m.SetToken(token)
+	d := m.Device()
+	d.Token = token
+
+	if err := d.GetControlInfo(); err != nil {
+		t.Fatalf("GetControlInfo() = %v", err)
+	}
+	if got := m.LastHeader().Get("X-Daikin-uuid"); got != token {
+		t.Errorf("GET X-Daikin-uuid = %q, want %q", got, token)
+	}
+
+	d.ControlInfo.Power = daikin.PowerOn
+	if err := d.SetControlInfo(); err != nil {
+		t.Fatalf("SetControlInfo() = %v", err)
+	}
+	if got := m.LastHeader().Get("X-Daikin-uuid"); got != token {
+		t.Errorf("POST X-Daikin-uuid = %q, want %q", got, token)
+	}
+	if got := m.State().Power; got != daikin.PowerOn {
+		t.Errorf("unit power = %v, want %v", got, daikin.PowerOn)
+	}
+}
+
+func TestTokenHeaderWrongToken(t *testing.T) {
+	m := daikintest.NewMockDevice(t)
+	m.SetToken("0123456789abcdef")
+	d := m.Device()
+	d.Token = "fedcba9876543210"
+	if err := d.GetControlInfo(); err == nil {
+		t.Error("GetControlInfo() with wrong token succeeded, want error")
+	}
+}
+
+func TestNoTokenHeader(t *testing.T) {
+	m := daikintest.NewMockDevice(t)
+	d := m.Device()
+
+	if err := d.GetControlInfo(); err != nil {
+		t.Fatalf("GetControlInfo() = %v", err)
+	}
+	if _, ok := m.LastHeader()["X-Daikin-Uuid"]; ok {
+		t.Errorf("GET sent X-Daikin-uuid header without a token")
+	}
+	if err := d.SetControlInfo(); err != nil {
+		t.Fatalf("SetControlInfo() = %v", err)
+	}
+	if _, ok := m.LastHeader()["X-Daikin-Uuid"]; ok {
+		t.Errorf("POST sent X-Daikin-uuid header without a token")
+	}
+}
@@ -0,0 +1,100 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// VacationMode holds the unit at a set temperature while the home is
+// empty. The control settings in effect when it starts are restored when
+// it ends.
+type VacationMode struct {
+	// StartDate is when vacation mode starts.
+	StartDate time.Time
+	// EndDate is when vacation mode ends.
+	EndDate time.Time
+	// AwayTemp is the set temperature while in vacation mode.
+	AwayTemp Temperature
+}
+
+// contains returns whether t is within the vacation.
+func (v *VacationMode) contains(t time.Time) bool {
+	return !t.Before(v.StartDate) && t.Before(v.EndDate)
+}
+
+// vacation is the vacation mode state of a unit.
+type vacation struct {
+	mu     sync.Mutex
+	mode   *VacationMode
+	active bool
+	// saved is the control settings to restore when leaving.
+	saved ControlInfo
+}
+
+// SetVacationMode schedules vacation mode for the unit, replacing any
+// previous schedule, and enters it now if the start date has passed. Tick
+// must be called periodically to enter and leave vacation mode at the
+// scheduled dates.
+func (d *Daikin) SetVacationMode(ctx context.Context, vm VacationMode) error {
+	if !vm.EndDate.After(vm.StartDate) {
+		return fmt.Errorf("vacation end %s is not after start %s", vm.EndDate.Format(time.RFC3339), vm.StartDate.Format(time.RFC3339))
+	}
+	if err := vm.AwayTemp.Validate(); err != nil {
+		return err
+	}
+	d.vacation.mu.Lock()
+	d.vacation.mode = &vm
+	d.vacation.mu.Unlock()
+	return d.Tick(ctx, time.Now())
+}
+
+// Tick enters or leaves vacation mode if now is past the start or end
+// date. On entering, the current control settings are fetched and saved,
+// and the unit set to the away temperature. On leaving, the saved settings
+// are restored and the vacation cleared.
+func (d *Daikin) Tick(ctx context.Context, now time.Time) error {
+	v := &d.vacation
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.mode == nil {
+		return nil
+	}
+	switch in := v.mode.contains(now); {
+	case in && !v.active:
+		if err := d.GetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		v.saved = *d.ControlInfo
+		c := v.saved
+		c.Temperature = v.mode.AwayTemp
+		d.ControlInfo = &c
+		if err := d.SetControlInfoContext(ctx); err != nil {
+			return err
+		}
+		v.active = true
+	case !in && v.active:
+		if err := d.RestoreSnapshot(ctx, v.saved); err != nil {
+			return err
+		}
+		v.active = false
+	}
+	if !now.Before(v.mode.EndDate) {
+		v.mode = nil
+	}
+	return nil
+}
+
+// GetEffectiveControlInfo returns the control settings of the unit, as
+// last fetched or set, with the away temperature substituted if it is in
+// vacation mode.
+func (d *Daikin) GetEffectiveControlInfo() ControlInfo {
+	c := d.Snapshot()
+	d.vacation.mu.Lock()
+	defer d.vacation.mu.Unlock()
+	if d.vacation.active {
+		c.Temperature = d.vacation.mode.AwayTemp
+	}
+	return c
+}
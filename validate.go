@@ -0,0 +1,53 @@
+package daikin
+
+import "fmt"
+
+// Validate checks the control settings for values the unit would reject
+// or alter, returning all violations found, or nil if there are none. If m
+// is non-nil, the settings are also checked against the model's
+// capabilities.
+func (c *ControlInfo) Validate(m *ModelInfo) []error {
+	var errs []error
+	if _, ok := powerMap[c.Power]; !ok {
+		errs = append(errs, &ErrUnknownValue{Type: "power", Value: fmt.Sprint(int(c.Power))})
+	}
+	if _, ok := modeMap[c.Mode]; !ok {
+		errs = append(errs, &ErrUnknownValue{Type: "mode", Value: fmt.Sprint(int(c.Mode))})
+	}
+	if _, ok := fanMap[c.Fan]; !ok {
+		errs = append(errs, &ErrUnknownValue{Type: "fan", Value: string(c.Fan)})
+	}
+	if _, ok := fanDirMap[c.FanDir]; !ok {
+		errs = append(errs, &ErrUnknownValue{Type: "fandir", Value: fmt.Sprint(int(c.FanDir))})
+	}
+	if err := c.Temperature.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if m != nil {
+		if err := m.checkTemperature(c); err != nil {
+			errs = append(errs, err)
+		}
+	} else if r, ok := temperatureRanges[c.Mode]; ok && (c.Temperature < r[0] || c.Temperature > r[1]) {
+		errs = append(errs, &ErrTemperatureOutOfRange{Requested: c.Temperature, Min: r[0], Max: r[1]})
+	}
+	// Units report a humidity of "-", decoded as -1, when it is not set.
+	if c.Humidity != -1 && (c.Humidity < 0 || c.Humidity > 100) {
+		errs = append(errs, fmt.Errorf("humidity %d is not within 0-100", c.Humidity))
+	}
+	// Units only run the fan automatically when dehumidifying.
+	if c.Mode == ModeDehumidify && c.Fan != FanAuto {
+		auto := FanAuto
+		errs = append(errs, fmt.Errorf("fan %s is not supported in mode %s, only %s", c.Fan.String(), c.Mode.String(), auto.String()))
+	}
+	if m != nil {
+		if err := m.checkFanDir(c); err != nil {
+			errs = append(errs, err)
+		}
+		for _, f := range controlFeatures {
+			if f.enabled(c) && !f.supported(m) {
+				errs = append(errs, &ErrUnsupported{Feature: f.name})
+			}
+		}
+	}
+	return errs
+}
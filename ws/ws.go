@@ -0,0 +1,167 @@
+// Package ws streams the state of Daikin units to WebSocket clients, eg
+// browser dashboards.
+//
+// Once connected, the server sends a JSON daikin.SensorSnapshot message for
+// each unit every interval. Clients may send ControlMessage messages to
+// change the control settings of a unit. Errors are sent as a JSON object
+// with an "error" field.
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/buxtronix/go-daikin"
+	"github.com/gorilla/websocket"
+)
+
+// defaultInterval is the polling interval used if none is given.
+const defaultInterval = 10 * time.Second
+
+// ControlMessage is sent by clients to change the control settings of a
+// unit. Fields omitted from Control are left unchanged.
+type ControlMessage struct {
+	// ID is the key of the unit in the devices map.
+	ID string `json:"id"`
+	// Control are the control settings to change, as JSON encoded
+	// daikin.ControlInfo fields.
+	Control json.RawMessage `json:"control"`
+}
+
+// StateServer is an http.Handler streaming the state of units over
+// WebSocket connections.
+type StateServer struct {
+	devices  map[string]*daikin.Daikin
+	interval time.Duration
+	upgrader websocket.Upgrader
+	logger   *slog.Logger
+}
+
+// LoggerOption configures the logger for errors. By default slog.Default()
+// is used.
+func LoggerOption(l *slog.Logger) func(*StateServer) {
+	return func(s *StateServer) {
+		s.logger = l
+	}
+}
+
+// NewStateServer returns a handler streaming the state of the devices,
+// keyed by their id, every interval. Each connection polls the devices
+// independently. If interval is not positive, ten seconds is used.
+func NewStateServer(devices map[string]*daikin.Daikin, interval time.Duration, opts ...func(*StateServer)) http.Handler {
+	s := &StateServer{
+		devices:  devices,
+		interval: interval,
+		logger:   slog.Default(),
+	}
+	if s.interval <= 0 {
+		s.interval = defaultInterval
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// conn is a client connection. Writes are serialised by mu.
+type conn struct {
+	ws *websocket.Conn
+	mu sync.Mutex
+}
+
+func (c *conn) writeJSON(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ws.WriteJSON(v)
+}
+
+func (c *conn) writeError(err error) error {
+	return c.writeJSON(map[string]string{"error": err.Error()})
+}
+
+// ServeHTTP implements http.Handler.
+func (s *StateServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied to the client.
+		s.logger.Debug("websocket upgrade failed", "err", err)
+		return
+	}
+	c := &conn{ws: ws}
+	defer ws.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		s.readControl(ctx, c)
+	}()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.sendState(ctx, c); err != nil {
+			s.logger.Debug("websocket write failed", "err", err)
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendState sends a snapshot of each device to the client. Devices which
+// fail are reported as errors.
+func (s *StateServer) sendState(ctx context.Context, c *conn) error {
+	for _, d := range s.devices {
+		snap, err := d.FetchSnapshot(ctx)
+		if err != nil {
+			if err := c.writeError(err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.writeJSON(snap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readControl applies control messages from the client until the
+// connection is closed.
+func (s *StateServer) readControl(ctx context.Context, c *conn) {
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg ControlMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.writeError(err)
+			continue
+		}
+		if err := s.setControl(ctx, &msg); err != nil {
+			c.writeError(err)
+		}
+	}
+}
+
+// setControl applies the control message to its device.
+func (s *StateServer) setControl(ctx context.Context, msg *ControlMessage) error {
+	d, ok := s.devices[msg.ID]
+	if !ok {
+		return errors.New("unknown device")
+	}
+	_, err := d.UpdateControlInfo(ctx, func(c *daikin.ControlInfo) error {
+		return json.Unmarshal(msg.Control, c)
+	})
+	return err
+}